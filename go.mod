@@ -3,9 +3,11 @@ module github.com/louislef299/wave-report-agent
 go 1.25.7
 
 require (
+	github.com/google/jsonschema-go v0.4.2
 	github.com/louislef299/claude-go-adk v0.0.0-20260217220333-b35a73ae485c
 	google.golang.org/adk v0.4.0
 	google.golang.org/genai v1.46.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 // keep here until claude-go-adk is versioned properly
@@ -23,7 +25,6 @@ require (
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/google/go-cmp v0.7.0 // indirect
-	github.com/google/jsonschema-go v0.4.2 // indirect
 	github.com/google/s2a-go v0.1.9 // indirect
 	github.com/google/safehtml v0.1.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
@@ -2,11 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/louislef299/claude-go-adk"
 	wagent "github.com/louislef299/wave-report-agent/pkg/agent"
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"github.com/louislef299/wave-report-agent/pkg/weather"
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/cmd/launcher"
 	"google.golang.org/adk/cmd/launcher/full"
@@ -17,6 +22,24 @@ import (
 
 func main() {
 	ctx := context.Background()
+	spotsPath, args, err := spotsFlag(os.Args[1:])
+	if err != nil {
+		log.Fatalf("%v\n\nUsage: %s [-spots path/to/spots.yaml] <launcher args>", err, os.Args[0])
+	}
+	if err := spot.Load(ctx, spotsPath); err != nil {
+		log.Fatalf("Failed to load spots: %v", err)
+	}
+
+	if len(args) > 0 && args[0] == "check-stations" {
+		os.Exit(checkStations(ctx))
+	}
+
+	go func() {
+		if err := weather.WarmGridPoints(ctx, spot.Default().Spots()); err != nil {
+			log.Printf("Failed to warm NWS gridpoint cache: %v", err)
+		}
+	}()
+
 	waveAgent, err := wagent.NewWaveAgent(ctx, getClaudeModel())
 	if err != nil {
 		log.Fatalf("Failed to create agent: %v", err)
@@ -27,11 +50,67 @@ func main() {
 	}
 
 	l := full.NewLauncher()
-	if err = l.Execute(ctx, config, os.Args[1:]); err != nil {
+	if err = l.Execute(ctx, config, args); err != nil {
 		log.Fatalf("Run failed: %v\n\n%s", err, l.CommandLineSyntax())
 	}
 }
 
+// spotsFlag extracts the -spots flag, which names the spots file and takes
+// precedence over $WAVE_SPOTS_FILE, from args. The remaining arguments are
+// passed on to the ADK launcher, which parses its own flags. A -spots without
+// a file name is an error rather than a silent fall back to the default list.
+func spotsFlag(args []string) (string, []string, error) {
+	path := ""
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !strings.HasPrefix(args[i], "-") || name != "spots" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", nil, errors.New("flag needs an argument: -spots")
+			}
+			i++
+			value = args[i]
+		}
+		if value == "" {
+			return "", nil, errors.New("flag needs a file name: -spots")
+		}
+		path = value
+	}
+	return path, rest, nil
+}
+
+// checkStations prints a report of every spot's configured station IDs and
+// returns a non-zero exit code if any look stale.
+func checkStations(ctx context.Context) int {
+	code := 0
+	spots := spot.Default().Spots()
+	tides := weather.CheckTideStations(ctx, spots)
+	for i, r := range weather.CheckNdbcStations(ctx, spots) {
+		fmt.Printf("%s: tide station %q\n", r.Spot, tides[i].ConfiguredID)
+		for _, b := range r.Stations {
+			fmt.Printf("  buoy %s (%s, weight %g)\n", b.ID, b.Role, b.Weight)
+		}
+		if b := r.NearestWaveBuoy; b != nil {
+			fmt.Printf("  nearest wave buoy:    %s %s (%.1f mi)\n", b.ID, b.Name, b.DistanceMiles)
+		}
+		if w := r.NearestWindStation; w != nil {
+			fmt.Printf("  nearest wind station: %s %s (%.1f mi)\n", w.ID, w.Name, w.DistanceMiles)
+		}
+		if t := tides[i].Nearest; t != nil && spots[i].SpotType == spot.SpotTypeOcean {
+			fmt.Printf("  nearest tide station: %s %s, %s (%.1f mi)\n", t.ID, t.Name, t.Type, t.DistanceMiles)
+		}
+		for _, w := range append(r.Warnings, tides[i].Warnings...) {
+			fmt.Printf("  WARNING: %s\n", w)
+			code = 1
+		}
+	}
+	return code
+}
+
 func getGeminiModel(ctx context.Context) model.LLM {
 	model, err := gemini.NewModel(ctx, "gemini-3-flash-preview", &genai.ClientConfig{
 		APIKey: os.Getenv("GOOGLE_API_KEY"),
@@ -3,17 +3,39 @@ package agent
 import (
 	"time"
 
+	"github.com/louislef299/wave-report-agent/pkg/spot"
 	"google.golang.org/adk/tool"
 )
 
-type GetDateArgs struct{}
+type GetDateArgs struct {
+	Spot string `json:"spot,omitempty" jsonschema_description:"Name or alias of a spot to report the date for in that spot's timezone. Defaults to the server's timezone."`
+}
 
 type GetDateResp struct {
-	Today string `json:"current_date"`
+	Today    string `json:"current_date" jsonschema_description:"Current time in RFC3339 with the UTC offset included."`
+	Date     string `json:"local_date" jsonschema_description:"Current calendar date as YYYY-MM-DD."`
+	Weekday  string `json:"weekday"`
+	Timezone string `json:"timezone" jsonschema_description:"IANA timezone the date is reported in, or the server's zone abbreviation when no spot is given."`
 }
 
 func getDate(_ tool.Context, a GetDateArgs) (GetDateResp, error) {
+	now := time.Now()
+	if a.Spot != "" {
+		matches, err := spot.MatchName(spot.Default().Spots(), a.Spot)
+		if err != nil {
+			return GetDateResp{}, err
+		}
+		now = matches[0].Now()
+	}
+
+	tz := now.Location().String()
+	if tz == "Local" {
+		tz, _ = now.Zone()
+	}
 	return GetDateResp{
-		Today: time.Now().Format(time.RFC3339),
+		Today:    now.Format(time.RFC3339),
+		Date:     now.Format(time.DateOnly),
+		Weekday:  now.Weekday().String(),
+		Timezone: tz,
 	}, nil
 }
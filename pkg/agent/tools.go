@@ -12,36 +12,132 @@ import (
 func getTools() []tool.Tool {
 	spotTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_spots_of_interest",
-		Description: "Returns the spots of interest for the agent. Use name='all' to return all configured surf spots.",
+		Description: "Returns the spots of interest for the agent. Use name='all' to return all configured surf spots. Names match aliases, partial names and word prefixes (e.g. 'emp' for Empire Beach) and typos; on a miss the error suggests close matches. Narrow results with the optional state, spot_type, break_type and region filters (e.g. name='all', spot_type='lake', state='MN').",
 	}, spot.GetSpotsOfInterest)
 	if err != nil {
 		log.Fatal("Failed to create time tool:", err)
 	}
 
+	nearbyTool, err := functiontool.New(functiontool.Config{
+		Name:        "find_spots_near",
+		Description: "Returns configured surf spots within radius_miles of a latitude/longitude, nearest first, with each spot's distance in miles and bearing from the origin. Use for questions like \"what's surfable within 50 miles of Duluth\" — look up the place's coordinates, then evaluate the returned spots.",
+	}, spot.FindSpotsNear)
+	if err != nil {
+		log.Fatal("Failed to create nearby spots tool:", err)
+	}
+
+	addSpotTool, err := functiontool.New(functiontool.Config{
+		Name:        "add_spot",
+		Description: "Adds a new surf spot to the watch list and saves it. Requires name, coordinates, spot_type, break_type and facing; include nearest_buoy_id and tide_station_id when known. Returns the updated watch list or the validation errors to fix.",
+	}, spot.AddSpot)
+	if err != nil {
+		log.Fatal("Failed to create add spot tool:", err)
+	}
+
+	updateSpotTool, err := functiontool.New(functiontool.Config{
+		Name:        "update_spot",
+		Description: "Replaces an existing surf spot in the watch list and saves it. Send the full spot, not just the changed fields. Returns the updated watch list or the validation errors to fix.",
+	}, spot.UpdateSpot)
+	if err != nil {
+		log.Fatal("Failed to create update spot tool:", err)
+	}
+
+	removeSpotTool, err := functiontool.New(functiontool.Config{
+		Name:        "remove_spot",
+		Description: "Removes a surf spot from the watch list by name and saves the change. Only call when the user explicitly asks to remove a spot.",
+	}, spot.RemoveSpot)
+	if err != nil {
+		log.Fatal("Failed to create remove spot tool:", err)
+	}
+
+	fetchTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_fetch",
+		Description: "Returns the fetch (open-water distance in miles the wind crosses before reaching the spot) for a lake spot, computed from the bundled Great Lakes shoreline. Pass wind_direction_deg (the direction the wind blows from) for that direction's fetch; the 16-point compass table is always included. Errors for spots that are not on a bundled lake.",
+	}, spot.GetFetch)
+	if err != nil {
+		log.Fatal("Failed to create fetch tool:", err)
+	}
+
 	nwsTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_spot_weather",
-		Description: "Returns the temperature, wind speed, forecast, and direction of a provided Spot.",
+		Description: "Returns the temperature, wind speed, forecast, and direction of a provided Spot. Each period includes the raw wind text plus parsed min/max/gust speeds in wind_unit (mph by default, or kt or km/h) and the wind direction in degrees.",
 	}, weather.GetNwsForecast)
 	if err != nil {
 		log.Fatal("Failed to create National Weather Service tool:", err)
 	}
 
+	nwsHourlyTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_nws_hourly_forecast",
+		Description: "Returns the NWS hourly forecast of a provided Spot: temperature, wind speed and direction, chance of precipitation and a short forecast for each hour, with wind speeds and gusts parsed into wind_unit (mph by default, or kt or km/h) and direction in degrees. Optionally limit it to a start/end window.",
+	}, weather.GetNwsHourlyForecast)
+	if err != nil {
+		log.Fatal("Failed to create NWS hourly forecast tool:", err)
+	}
+
+	nwsGridTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_nws_grid_data",
+		Description: "Returns the raw NWS gridpoint forecast of a provided Spot as numeric hourly series: temperature in °F, wind speed and gusts in mph, wind direction, sky cover and chance of precipitation in percent, visibility in miles and, for coastal and Great Lakes grids, wave height in feet. Optionally limit it to a start/end window.",
+	}, weather.GetNwsGridData)
+	if err != nil {
+		log.Fatal("Failed to create NWS grid data tool:", err)
+	}
+
+	marineZoneTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_marine_zone_forecast",
+		Description: "Returns the latest NWS nearshore or coastal waters forecast for a provided Spot's marine zone (e.g. LSZ145 on Lake Superior): active headlines such as Small Craft Advisories, and for each period the full text plus parsed wind direction, wind and gust speeds in knots and wave heights in feet. The authoritative NWS forecast for Great Lakes spots, where get_spot_weather is often empty. Errors when the spot has no marine zone.",
+	}, weather.GetMarineZoneForecast)
+	if err != nil {
+		log.Fatal("Failed to create marine zone forecast tool:", err)
+	}
+
+	surfZoneTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_surf_zone_forecast",
+		Description: "Returns the latest NWS Surf Zone Forecast for a provided ocean Spot's beach zone: active hazards such as High Surf Advisories, and for each day the rip current risk (Low, Moderate or High), surf height range in feet, water temperature range in °F, per-day hazards and every other forecast line (weather, winds, tides). Errors when the spot's NWS office doesn't issue Surf Zone Forecasts.",
+	}, weather.GetSurfZoneForecast)
+	if err != nil {
+		log.Fatal("Failed to create surf zone forecast tool:", err)
+	}
+
 	openMetroTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_spot_marine_forecast",
-		Description: "Returns hourly marine forecast information of a provided Spot. Used with all SpotTypes.",
-	}, weather.GetHourlyMarineForecast)
+		Description: "Returns the Open-Meteo marine forecast of a provided Spot. Used with all SpotTypes. Optionally limit it to a start/end window, fetch up to 16 forecast_days or up to 92 past_days, thin it to every step_hours hour, or set daily for a compact min/max/mean summary per local day.",
+	}, weather.GetMarineForecast)
 	if err != nil {
 		log.Fatal("Failed to create Open Metro tool:", err)
 	}
 
+	windTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_spot_wind_forecast",
+		Description: "Returns the hourly Open-Meteo wind forecast of a provided Spot: sustained wind and gusts in mph, wind direction, air temperature in °F and sea level pressure. Works for every SpotType, including lake spots where the NWS grid forecast is often unavailable.",
+	}, weather.GetHourlyWindForecast)
+	if err != nil {
+		log.Fatal("Failed to create wind forecast tool:", err)
+	}
+
+	modelsTool, err := functiontool.New(functiontool.Config{
+		Name:        "compare_wave_models",
+		Description: "Compares the hourly wave height, period and direction of several Open-Meteo wave models (ECMWF WAM, GFS Wave, DWD ICON GWAM, MeteoFrance MFWAM) for a provided Spot, with per-hour spread and an overall confidence score and summary. Defaults to the next 3 days; optionally limit it to a start/end window or thin it to every step_hours hour.",
+	}, weather.CompareWaveModels)
+	if err != nil {
+		log.Fatal("Failed to create wave model comparison tool:", err)
+	}
+
+	wetsuitTool, err := functiontool.New(functiontool.Config{
+		Name:        "recommend_wetsuit",
+		Description: "Recommends a wetsuit thickness plus boots, gloves and hood for a session at a provided Spot. Uses a buoy water temperature from the last 3 hours when available, otherwise the Open-Meteo sea surface temperature forecast, and steps warmer for cold wind chill during the session or sessions of 3+ hours. Pass the session start (defaults to now) and length in hours (defaults to 2).",
+	}, weather.RecommendWetsuit)
+	if err != nil {
+		log.Fatal("Failed to create wetsuit tool:", err)
+	}
+
 	currentDateTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_current_date",
-		Description: "Returns the current date in RFC3339 format so agent can gather bearings. Only required if the current date is required & unknown.",
+		Description: "Returns the current date and time in RFC3339 format so agent can gather bearings. Pass a spot name to get the spot's local date, weekday and timezone. Only required if the current date is required & unknown.",
 	}, getDate)
 
 	buoyTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_buoy_observations",
-		Description: "Returns the latest real-time buoy observations (wave height, dominant period, mean wave direction, wind speed, wind direction) from the nearest NOAA NDBC buoy to the spot. Use this to validate forecast data against actual conditions and identify discrepancies.",
+		Description: "Returns the latest real-time observations (wave height, dominant period, mean wave direction, wind speed, wind direction) from every NOAA NDBC station configured for the spot, each with its role (waves, wind or upstream_swell) and weight, plus a weighted blend. Use the blended observation to validate forecast data against actual conditions and identify discrepancies; use upstream_swell stations as early warning of incoming swell.",
 	}, weather.GetBuoyObservations)
 	if err != nil {
 		log.Fatal("Failed to create buoy tool:", err)
@@ -49,7 +145,7 @@ func getTools() []tool.Tool {
 
 	tidesTool, err := functiontool.New(functiontool.Config{
 		Name:        "get_tide_predictions",
-		Description: "Returns today's and tomorrow's high and low tide predictions (local time, height in feet relative to MLLW) from the nearest NOAA CO-OPS tide gauge station. Returns nil for lake spots where tides are negligible. Use this to identify the best low-to-mid tide session window.",
+		Description: "Returns today's and tomorrow's high and low tide predictions (local time, height in feet relative to MLLW) from the nearest NOAA CO-OPS tide gauge station. Ocean spots without a configured station use the nearest CO-OPS prediction station, and subordinate stations are derived from their reference station's offsets. Returns nil for lake spots where tides are negligible. Use this to identify the best low-to-mid tide session window.",
 	}, weather.GetTidePredictions)
 	if err != nil {
 		log.Fatal("Failed to create tides tool:", err)
@@ -63,13 +159,52 @@ func getTools() []tool.Tool {
 		log.Fatal("Failed to create alerts tool:", err)
 	}
 
+	swellTool, err := functiontool.New(functiontool.Config{
+		Name:        "get_effective_swell",
+		Description: "Returns how much swell energy actually reaches the spot after island and headland shadowing (e.g. the Channel Islands). Evaluates a provided swell direction and height, the latest buoy observation (source='buoy'), or every hour of the marine swell forecast (source='forecast'). Use the effective height, not the open-water height, when rating swell size for ocean spots.",
+	}, weather.GetEffectiveSwell)
+	if err != nil {
+		log.Fatal("Failed to create effective swell tool:", err)
+	}
+
+	stationsTool, err := functiontool.New(functiontool.Config{
+		Name:        "suggest_buoy_stations",
+		Description: "Checks each of the spot's configured NDBC stations against NDBC's active station list and returns the nearest wave-reporting buoy and nearest wind station with their distances, plus warnings if a configured station is unknown, doesn't measure what its role needs (e.g. a C-MAN shore station used for waves) or is far from the spot. Use when adding a spot or when buoy observations look wrong.",
+	}, weather.SuggestNdbcStations)
+	if err != nil {
+		log.Fatal("Failed to create buoy station tool:", err)
+	}
+
+	tideStationTool, err := functiontool.New(functiontool.Config{
+		Name:        "suggest_tide_station",
+		Description: "Checks the spot's configured NOAA CO-OPS tide station and returns the nearest tide prediction station with its distance and whether it is a harmonic or subordinate station. Use when adding an ocean spot without a known tide_station_id.",
+	}, weather.SuggestTideStation)
+	if err != nil {
+		log.Fatal("Failed to create tide station tool:", err)
+	}
+
 	return []tool.Tool{
 		spotTool,
+		nearbyTool,
+		addSpotTool,
+		updateSpotTool,
+		removeSpotTool,
+		fetchTool,
 		nwsTool,
+		nwsHourlyTool,
+		nwsGridTool,
+		marineZoneTool,
+		surfZoneTool,
 		openMetroTool,
+		modelsTool,
+		wetsuitTool,
+		windTool,
 		currentDateTool,
 		buoyTool,
 		tidesTool,
 		alertsTool,
+		swellTool,
+		stationsTool,
+		tideStationTool,
 	}
 }
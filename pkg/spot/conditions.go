@@ -0,0 +1,205 @@
+package spot
+
+import (
+	"fmt"
+)
+
+// Range is an inclusive numeric window. A nil bound is open-ended.
+type Range struct {
+	Min *float64 `json:"min,omitempty" jsonschema_description:"Inclusive lower bound. Omitted when there is no lower bound."`
+	Max *float64 `json:"max,omitempty" jsonschema_description:"Inclusive upper bound. Omitted when there is no upper bound."`
+}
+
+// Between returns a Range bounded on both sides.
+func Between(lo, hi float64) *Range {
+	return &Range{Min: &lo, Max: &hi}
+}
+
+// AtLeast returns a Range with only a lower bound.
+func AtLeast(lo float64) *Range {
+	return &Range{Min: &lo}
+}
+
+// AtMost returns a Range with only an upper bound.
+func AtMost(hi float64) *Range {
+	return &Range{Max: &hi}
+}
+
+// Contains reports whether v falls within the range. A nil Range contains
+// every value.
+func (r *Range) Contains(v float64) bool {
+	if r == nil {
+		return true
+	}
+	if r.Min != nil && v < *r.Min {
+		return false
+	}
+	if r.Max != nil && v > *r.Max {
+		return false
+	}
+	return true
+}
+
+func (r *Range) valid() bool {
+	return r == nil || r.Min == nil || r.Max == nil || *r.Min <= *r.Max
+}
+
+func (r *Range) String() string {
+	switch {
+	case r == nil || (r.Min == nil && r.Max == nil):
+		return "any"
+	case r.Min == nil:
+		return fmt.Sprintf("<=%g", *r.Max)
+	case r.Max == nil:
+		return fmt.Sprintf(">=%g", *r.Min)
+	}
+	return fmt.Sprintf("%g-%g", *r.Min, *r.Max)
+}
+
+// DirectionArc is a compass sector running clockwise from From to To, so an arc
+// from 330 to 30 covers north.
+type DirectionArc struct {
+	From Direction `json:"from" jsonschema_description:"Start of the sector in degrees true."`
+	To   Direction `json:"to" jsonschema_description:"End of the sector in degrees true, measured clockwise from 'from'."`
+}
+
+// Contains reports whether d lies within the arc.
+func (a DirectionArc) Contains(d Direction) bool {
+	width := a.Width()
+	offset := normalizeDegrees(float64(d) - float64(a.From))
+	return offset <= width
+}
+
+// Distance returns how many degrees d lies outside the arc, or 0 when the arc
+// contains it.
+func (a DirectionArc) Distance(d Direction) float64 {
+	if a.Contains(d) {
+		return 0
+	}
+	return min(a.From.Diff(d), a.To.Diff(d))
+}
+
+// Width returns the angular size of the arc in degrees.
+func (a DirectionArc) Width() float64 {
+	return normalizeDegrees(float64(a.To) - float64(a.From))
+}
+
+func (a DirectionArc) String() string {
+	return fmt.Sprintf("%g-%g°", float64(a.From), float64(a.To))
+}
+
+// TidePhase is the preferred direction of tidal movement during a session.
+type TidePhase string
+
+const (
+	TidePhaseAny     TidePhase = ""
+	TidePhaseRising  TidePhase = "rising"
+	TidePhaseFalling TidePhase = "falling"
+)
+
+// Valid reports whether p is a known tide phase.
+func (p TidePhase) Valid() bool {
+	switch p {
+	case TidePhaseAny, TidePhaseRising, TidePhaseFalling:
+		return true
+	}
+	return false
+}
+
+// Conditions describes the window in which a spot works best. Every field is
+// optional; an unset field means the spot has no particular preference.
+type Conditions struct {
+	SwellDirections []DirectionArc `json:"swell_directions,omitempty" jsonschema_description:"Compass sectors (degrees true, where swell comes FROM) that work at the spot."`
+	SwellPeriodS    *Range         `json:"swell_period_s,omitempty" jsonschema_description:"Ideal swell period in seconds."`
+	SwellHeightFt   *Range         `json:"swell_height_ft,omitempty" jsonschema_description:"Ideal swell height in feet."`
+	MaxHoldableFt   *float64       `json:"max_holdable_ft,omitempty" jsonschema_description:"Wave height in feet above which the spot closes out or becomes unmanageable."`
+	OffshoreWind    *DirectionArc  `json:"offshore_wind,omitempty" jsonschema_description:"Compass sector (degrees true, where wind comes FROM) that blows offshore at the spot."`
+	TideFt          *Range         `json:"tide_ft,omitempty" jsonschema_description:"Ideal tide height window in feet relative to MLLW. Omitted for lake spots."`
+	TidePhase       TidePhase      `json:"tide_phase,omitempty" jsonschema_description:"Preferred tide movement: 'rising' or 'falling'. Omitted when either works."`
+}
+
+// SwellDirectionOK reports whether swell from d falls within one of the spot's
+// working sectors.
+func (c *Conditions) SwellDirectionOK(d Direction) bool {
+	if c == nil || len(c.SwellDirections) == 0 {
+		return true
+	}
+	for _, a := range c.SwellDirections {
+		if a.Contains(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// SwellPeriodOK reports whether period falls within the ideal swell period.
+func (c *Conditions) SwellPeriodOK(periodS float64) bool {
+	return c == nil || c.SwellPeriodS.Contains(periodS)
+}
+
+// SwellHeightOK reports whether height falls within the ideal swell size.
+func (c *Conditions) SwellHeightOK(heightFt float64) bool {
+	return c == nil || c.SwellHeightFt.Contains(heightFt)
+}
+
+// Holds reports whether the spot can handle waves of the given height.
+func (c *Conditions) Holds(heightFt float64) bool {
+	return c == nil || c.MaxHoldableFt == nil || heightFt <= *c.MaxHoldableFt
+}
+
+// Offshore reports whether wind from d blows offshore. Spots without an
+// offshore sector never report offshore wind.
+func (c *Conditions) Offshore(d Direction) bool {
+	return c != nil && c.OffshoreWind != nil && c.OffshoreWind.Contains(d)
+}
+
+// TideOK reports whether the tide height and phase match the spot's
+// preference. Pass TidePhaseAny when the phase is unknown.
+func (c *Conditions) TideOK(heightFt float64, phase TidePhase) bool {
+	if c == nil {
+		return true
+	}
+	if c.TidePhase != TidePhaseAny && phase != TidePhaseAny && c.TidePhase != phase {
+		return false
+	}
+	return c.TideFt.Contains(heightFt)
+}
+
+// validate returns a FieldError for every inconsistent window.
+func (c *Conditions) validate() []*FieldError {
+	if c == nil {
+		return nil
+	}
+
+	var fields []*FieldError
+	for i, a := range c.SwellDirections {
+		if !a.From.Valid() || !a.To.Valid() {
+			fields = append(fields, &FieldError{Field: fmt.Sprintf("optimal.swell_directions[%d]", i), Value: a, Err: ErrOutOfRange})
+		}
+	}
+	if a := c.OffshoreWind; a != nil && (!a.From.Valid() || !a.To.Valid()) {
+		fields = append(fields, &FieldError{Field: "optimal.offshore_wind", Value: *a, Err: ErrOutOfRange})
+	}
+
+	ranges := []struct {
+		field string
+		r     *Range
+	}{
+		{"optimal.swell_period_s", c.SwellPeriodS},
+		{"optimal.swell_height_ft", c.SwellHeightFt},
+		{"optimal.tide_ft", c.TideFt},
+	}
+	for _, r := range ranges {
+		if !r.r.valid() {
+			fields = append(fields, &FieldError{Field: r.field, Value: r.r, Err: ErrOutOfRange})
+		}
+	}
+
+	if c.MaxHoldableFt != nil && *c.MaxHoldableFt <= 0 {
+		fields = append(fields, &FieldError{Field: "optimal.max_holdable_ft", Value: *c.MaxHoldableFt, Err: ErrOutOfRange})
+	}
+	if !c.TidePhase.Valid() {
+		fields = append(fields, &FieldError{Field: "optimal.tide_phase", Value: c.TidePhase, Err: ErrInvalidValue})
+	}
+	return fields
+}
@@ -0,0 +1,61 @@
+package spot
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDirectionArcContains(t *testing.T) {
+	testCases := []struct {
+		arc      DirectionArc
+		dir      Direction
+		expected bool
+	}{
+		{arc: DirectionArc{From: 250, To: 280}, dir: 265, expected: true},
+		{arc: DirectionArc{From: 250, To: 280}, dir: 280, expected: true},
+		{arc: DirectionArc{From: 250, To: 280}, dir: 290, expected: false},
+		{arc: DirectionArc{From: 330, To: 30}, dir: 0, expected: true},
+		{arc: DirectionArc{From: 330, To: 30}, dir: 15, expected: true},
+		{arc: DirectionArc{From: 330, To: 30}, dir: 180, expected: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%s contains %g", tt.arc, tt.dir.Degrees()), func(t *testing.T) {
+			if got := tt.arc.Contains(tt.dir); got != tt.expected {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConditions(t *testing.T) {
+	rincon := &Conditions{
+		SwellDirections: []DirectionArc{{From: 250, To: 280}},
+		SwellPeriodS:    Between(13, 16),
+		SwellHeightFt:   Between(4, 8),
+		OffshoreWind:    &DirectionArc{From: 22.5, To: 67.5},
+		TideFt:          AtLeast(2),
+		TidePhase:       TidePhaseFalling,
+	}
+
+	if !rincon.SwellDirectionOK(265) || rincon.SwellDirectionOK(200) {
+		t.Errorf("swell direction window not applied")
+	}
+	if !rincon.SwellPeriodOK(14) || rincon.SwellPeriodOK(18) {
+		t.Errorf("swell period window not applied")
+	}
+	if !rincon.SwellHeightOK(5) || rincon.SwellHeightOK(2) {
+		t.Errorf("swell height window not applied")
+	}
+	if !rincon.Offshore(45) || rincon.Offshore(225) {
+		t.Errorf("offshore wind sector not applied")
+	}
+	if !rincon.TideOK(3, TidePhaseFalling) || rincon.TideOK(3, TidePhaseRising) || rincon.TideOK(1, TidePhaseAny) {
+		t.Errorf("tide window not applied")
+	}
+
+	var none *Conditions
+	if !none.SwellDirectionOK(90) || !none.Holds(20) || none.Offshore(90) {
+		t.Errorf("nil conditions should accept everything but never report offshore")
+	}
+}
@@ -0,0 +1,102 @@
+package spot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidDirection = errors.New("direction must be a 16-point compass abbreviation or degrees in [0, 360)")
+
+// compassPoints are the 16 compass abbreviations in clockwise order starting at
+// north, each 22.5° apart.
+var compassPoints = [16]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// Direction is a bearing in degrees true, clockwise from north. It encodes to
+// JSON as a number but decodes from either a number or a compass abbreviation
+// such as "WSW", so spot files can use whichever is more natural.
+type Direction float64
+
+// ParseDirection parses a compass abbreviation (e.g. "WSW") or a number of
+// degrees (e.g. "247.5").
+func ParseDirection(s string) (Direction, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for i, p := range compassPoints {
+		if s == p {
+			return Direction(float64(i) * 22.5), nil
+		}
+	}
+
+	deg, err := strconv.ParseFloat(strings.TrimSuffix(s, "°"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDirection, s)
+	}
+	d := Direction(deg)
+	if !d.Valid() {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDirection, s)
+	}
+	return d, nil
+}
+
+// Degrees returns the bearing as a plain float.
+func (d Direction) Degrees() float64 {
+	return float64(d)
+}
+
+// Valid reports whether the bearing is within [0, 360).
+func (d Direction) Valid() bool {
+	return d >= 0 && d < 360
+}
+
+// Compass returns the nearest 16-point compass abbreviation.
+func (d Direction) Compass() string {
+	i := int(math.Round(normalizeDegrees(float64(d))/22.5)) % 16
+	return compassPoints[i]
+}
+
+// Diff returns the smallest angle between d and o, in [0, 180].
+func (d Direction) Diff(o Direction) float64 {
+	diff := math.Abs(normalizeDegrees(float64(d)) - normalizeDegrees(float64(o)))
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
+
+func (d Direction) String() string {
+	return fmt.Sprintf("%s (%g°)", d.Compass(), float64(d))
+}
+
+func (d *Direction) UnmarshalJSON(b []byte) error {
+	var deg float64
+	if err := json.Unmarshal(b, &deg); err == nil {
+		*d = Direction(deg)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDirection, b)
+	}
+	parsed, err := ParseDirection(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// normalizeDegrees wraps deg into [0, 360).
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
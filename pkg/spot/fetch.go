@@ -0,0 +1,231 @@
+package spot
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"google.golang.org/adk/tool"
+)
+
+const (
+	// fetchStepMiles is the resolution of the fetch ray cast.
+	fetchStepMiles = 0.5
+	// maxFetchMiles caps a ray, comfortably longer than any Great Lake.
+	maxFetchMiles = 400
+	// shoreToleranceMiles is how far a ray may cross land before reaching
+	// water and still count as leaving the spot's beach. Spots sit on the
+	// shoreline, which the simplified polygons only approximate.
+	shoreToleranceMiles = 3
+	// lakeSnapMiles is how far a spot may be from a bundled shoreline and
+	// still be matched to that lake.
+	lakeSnapMiles = 10
+)
+
+var ErrNoFetchGeometry = errors.New("no bundled lake shoreline near the spot")
+
+//go:embed data/great_lakes.json
+var bundledLakes []byte
+
+// lake is a bundled lake shoreline polygon.
+type lake struct {
+	Name      string       `json:"name"`
+	Shoreline [][2]float64 `json:"shoreline"`
+}
+
+var (
+	lakesOnce sync.Once
+	lakes     []lake
+
+	fetchMu    sync.Mutex
+	fetchCache = make(map[[2]float32]*FetchTable)
+)
+
+func loadLakes() []lake {
+	lakesOnce.Do(func() {
+		var raw struct {
+			Lakes []lake `json:"lakes"`
+		}
+		if err := json.NewDecoder(bytes.NewReader(bundledLakes)).Decode(&raw); err != nil {
+			panic(err)
+		}
+		lakes = raw.Lakes
+	})
+	return lakes
+}
+
+// contains reports whether a coordinate is on the water side of the shoreline
+// using even-odd ray casting.
+func (l *lake) contains(lat, lon float64) bool {
+	in := false
+	pts := l.Shoreline
+	for i, j := 0, len(pts)-1; i < len(pts); j, i = i, i+1 {
+		lat1, lon1 := pts[i][0], pts[i][1]
+		lat2, lon2 := pts[j][0], pts[j][1]
+		if (lat1 > lat) != (lat2 > lat) && lon < (lon2-lon1)*(lat-lat1)/(lat2-lat1)+lon1 {
+			in = !in
+		}
+	}
+	return in
+}
+
+// distanceMiles returns the approximate distance from a coordinate to the
+// nearest point of the shoreline, projecting locally onto a flat plane.
+func (l *lake) distanceMiles(lat, lon float64) float64 {
+	milesPerLat := earthRadiusMiles * math.Pi / 180
+	milesPerLon := milesPerLat * math.Cos(radians(lat))
+	project := func(p [2]float64) (float64, float64) {
+		return (p[1] - lon) * milesPerLon, (p[0] - lat) * milesPerLat
+	}
+
+	best := math.Inf(1)
+	pts := l.Shoreline
+	for i, j := 0, len(pts)-1; i < len(pts); j, i = i, i+1 {
+		x1, y1 := project(pts[j])
+		x2, y2 := project(pts[i])
+		dx, dy := x2-x1, y2-y1
+
+		// Closest point on the segment to the origin.
+		t := 0.0
+		if l2 := dx*dx + dy*dy; l2 > 0 {
+			t = math.Max(0, math.Min(1, -(x1*dx+y1*dy)/l2))
+		}
+		best = math.Min(best, math.Hypot(x1+t*dx, y1+t*dy))
+	}
+	return best
+}
+
+// fetch casts a ray from a shoreline coordinate towards bearing and returns
+// the open-water distance before it reaches land again, or 0 when the bearing
+// points inland or only clips the spot's own shoreline.
+func (l *lake) fetch(lat, lon float64, bearing Direction) float64 {
+	start := -1.0
+	for d := 0.0; d <= maxFetchMiles; d += fetchStepMiles {
+		in := l.contains(Destination(lat, lon, bearing, d))
+		switch {
+		case start < 0 && in:
+			start = d
+		case start < 0 && d > shoreToleranceMiles:
+			return 0
+		case start >= 0 && !in && d <= shoreToleranceMiles:
+			return 0
+		case start >= 0 && !in:
+			return d
+		}
+	}
+	return maxFetchMiles
+}
+
+// nearestLake returns the bundled lake the coordinate lies on or beside.
+func nearestLake(lat, lon float64) (*lake, bool) {
+	var (
+		best *lake
+		dist = math.Inf(1)
+	)
+	for i := range loadLakes() {
+		l := &lakes[i]
+		if l.contains(lat, lon) {
+			return l, true
+		}
+		if d := l.distanceMiles(lat, lon); d < dist {
+			best, dist = l, d
+		}
+	}
+	return best, dist <= lakeSnapMiles
+}
+
+// DirectionFetch is the open-water distance upwind of a spot for one wind
+// direction.
+type DirectionFetch struct {
+	DirectionDeg float64 `json:"direction_deg" jsonschema_description:"Wind direction in degrees true, i.e. the direction the wind blows from."`
+	Direction    string  `json:"direction" jsonschema_description:"Wind direction as a 16-point compass abbreviation, e.g. 'SW'."`
+	Miles        float64 `json:"miles" jsonschema_description:"Open water the wind crosses before reaching the spot, in statute miles. 0 when the wind blows off the land."`
+}
+
+// FetchTable is a spot's fetch for each of the 16 compass directions.
+type FetchTable struct {
+	Lake       string           `json:"lake"`
+	Directions []DirectionFetch `json:"directions"`
+}
+
+// Fetch returns the open-water distance in miles upwind of the spot for wind
+// blowing from dir.
+func (s *Spot) Fetch(dir Direction) (float64, string, error) {
+	lat, lon := float64(s.Latitude), float64(s.Longitude)
+	l, ok := nearestLake(lat, lon)
+	if !ok {
+		return 0, "", fmt.Errorf("%s: %w", s.Name, ErrNoFetchGeometry)
+	}
+	return math.Round(l.fetch(lat, lon, dir)), l.Name, nil
+}
+
+// FetchTable computes the spot's fetch for the 16 compass directions by ray
+// casting across the bundled shoreline of its lake. Tables are cached by
+// location.
+func (s *Spot) FetchTable() (*FetchTable, error) {
+	key := [2]float32{s.Latitude, s.Longitude}
+	fetchMu.Lock()
+	defer fetchMu.Unlock()
+	if t, ok := fetchCache[key]; ok {
+		return t, nil
+	}
+
+	t := &FetchTable{Directions: make([]DirectionFetch, 0, 16)}
+	for i := range 16 {
+		dir := Direction(float64(i) * 22.5)
+		miles, name, err := s.Fetch(dir)
+		if err != nil {
+			return nil, err
+		}
+		t.Lake = name
+		t.Directions = append(t.Directions, DirectionFetch{
+			DirectionDeg: dir.Degrees(),
+			Direction:    dir.Compass(),
+			Miles:        miles,
+		})
+	}
+	fetchCache[key] = t
+	return t, nil
+}
+
+type FetchArgs struct {
+	Name             string   `json:"name" jsonschema_description:"The name or alias of the lake spot."`
+	WindDirectionDeg *float64 `json:"wind_direction_deg,omitempty" jsonschema_description:"Wind direction in degrees true (the direction the wind blows from). Omit to get only the 16-point table."`
+}
+
+type FetchResult struct {
+	Spot  string           `json:"spot"`
+	Lake  string           `json:"lake"`
+	Wind  *DirectionFetch  `json:"wind,omitempty" jsonschema_description:"Fetch for the requested wind direction."`
+	Table []DirectionFetch `json:"table" jsonschema_description:"Fetch for each of the 16 compass directions."`
+}
+
+// GetFetch returns how much open water the wind crosses before reaching a lake
+// spot, for a given wind direction and as a 16-point table.
+func GetFetch(_ tool.Context, args FetchArgs) (FetchResult, error) {
+	matches, err := MatchName(defaultRegistry.Spots(), args.Name)
+	if err != nil {
+		return FetchResult{}, err
+	}
+	s := &matches[0]
+
+	table, err := s.FetchTable()
+	if err != nil {
+		return FetchResult{}, err
+	}
+	res := FetchResult{Spot: s.Name, Lake: table.Lake, Table: table.Directions}
+
+	if args.WindDirectionDeg != nil {
+		dir := Direction(normalizeDegrees(*args.WindDirectionDeg))
+		miles, _, err := s.Fetch(dir)
+		if err != nil {
+			return FetchResult{}, err
+		}
+		res.Wind = &DirectionFetch{DirectionDeg: dir.Degrees(), Direction: dir.Compass(), Miles: miles}
+	}
+	return res, nil
+}
@@ -0,0 +1,70 @@
+package spot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSpotFetch(t *testing.T) {
+	empire := &spots[2]
+	stoney := &spots[3]
+
+	testCases := []struct {
+		spot     *Spot
+		dir      Direction
+		min, max float64
+		lake     string
+	}{
+		// Across Lake Michigan to the Door Peninsula.
+		{spot: empire, dir: 270, min: 50, max: 70, lake: "Lake Michigan"},
+		{spot: empire, dir: 225, min: 100, max: 150, lake: "Lake Michigan"},
+		{spot: empire, dir: 90, min: 0, max: 0, lake: "Lake Michigan"},
+		// Down the length of Lake Superior.
+		{spot: stoney, dir: 67.5, min: 200, max: 350, lake: "Lake Superior"},
+		{spot: stoney, dir: 315, min: 0, max: 0, lake: "Lake Superior"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%s from %s", tt.spot.Name, tt.dir.Compass()), func(t *testing.T) {
+			miles, lake, err := tt.spot.Fetch(tt.dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if lake != tt.lake {
+				t.Fatalf("Expected lake %s, got %s", tt.lake, lake)
+			}
+			if miles < tt.min || miles > tt.max {
+				t.Fatalf("Expected fetch between %.0f and %.0f miles, got %.0f", tt.min, tt.max, miles)
+			}
+		})
+	}
+}
+
+func TestFetchTable(t *testing.T) {
+	table, err := spots[2].FetchTable()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(table.Directions) != 16 {
+		t.Fatalf("Expected 16 directions, got %d", len(table.Directions))
+	}
+	if d := table.Directions[4]; d.Direction != "E" || d.DirectionDeg != 90 {
+		t.Fatalf("Expected index 4 to be E at 90°, got %+v", d)
+	}
+
+	if _, err := spots[0].FetchTable(); !errors.Is(err, ErrNoFetchGeometry) {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrNoFetchGeometry)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	lat, lon := Destination(46.7867, -92.1005, 202.5, 100)
+	if d := DistanceMiles(46.7867, -92.1005, lat, lon); math.Abs(d-100) > 0.1 {
+		t.Fatalf("Expected destination 100 miles away, got %.2f", d)
+	}
+	if b := InitialBearing(46.7867, -92.1005, lat, lon); math.Abs(b.Degrees()-202.5) > 0.1 {
+		t.Fatalf("Expected bearing 202.5°, got %.2f", b.Degrees())
+	}
+}
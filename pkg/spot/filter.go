@@ -0,0 +1,64 @@
+package spot
+
+import (
+	"strings"
+)
+
+// stateNames maps US postal abbreviations to state names so filters accept
+// either form.
+var stateNames = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas",
+	"CA": "California", "CO": "Colorado", "CT": "Connecticut", "DE": "Delaware",
+	"FL": "Florida", "GA": "Georgia", "HI": "Hawaii", "ID": "Idaho",
+	"IL": "Illinois", "IN": "Indiana", "IA": "Iowa", "KS": "Kansas",
+	"KY": "Kentucky", "LA": "Louisiana", "ME": "Maine", "MD": "Maryland",
+	"MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota", "MS": "Mississippi",
+	"MO": "Missouri", "MT": "Montana", "NE": "Nebraska", "NV": "Nevada",
+	"NH": "New Hampshire", "NJ": "New Jersey", "NM": "New Mexico", "NY": "New York",
+	"NC": "North Carolina", "ND": "North Dakota", "OH": "Ohio", "OK": "Oklahoma",
+	"OR": "Oregon", "PA": "Pennsylvania", "RI": "Rhode Island", "SC": "South Carolina",
+	"SD": "South Dakota", "TN": "Tennessee", "TX": "Texas", "UT": "Utah",
+	"VT": "Vermont", "VA": "Virginia", "WA": "Washington", "WV": "West Virginia",
+	"WI": "Wisconsin", "WY": "Wyoming", "PR": "Puerto Rico",
+}
+
+// sameState reports whether a and b name the same state, comparing full names
+// and postal abbreviations case-insensitively.
+func sameState(a, b string) bool {
+	expand := func(s string) string {
+		s = strings.TrimSpace(s)
+		if full, ok := stateNames[strings.ToUpper(s)]; ok {
+			return full
+		}
+		return s
+	}
+	return strings.EqualFold(expand(a), expand(b))
+}
+
+// matches reports whether s satisfies every filter set on args. Unset filters
+// match everything.
+func (args SpotArgs) matches(s Spot) bool {
+	if args.State != "" && !sameState(args.State, s.State) {
+		return false
+	}
+	if args.SpotType != "" && args.SpotType != s.SpotType {
+		return false
+	}
+	if args.BreakType != "" && args.BreakType != s.BreakType {
+		return false
+	}
+	if args.Region != "" && !strings.Contains(strings.ToLower(s.Region), strings.ToLower(strings.TrimSpace(args.Region))) {
+		return false
+	}
+	return true
+}
+
+func filterSpots(spots []Spot, args SpotArgs) []Spot {
+	filtered := make([]Spot, 0, len(spots))
+	for _, s := range spots {
+		if args.matches(s) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
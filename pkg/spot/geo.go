@@ -0,0 +1,56 @@
+package spot
+
+import (
+	"math"
+)
+
+// earthRadiusMiles is the mean radius of the Earth.
+const earthRadiusMiles = 3958.8
+
+// DistanceMiles returns the great-circle distance between two coordinates
+// using the haversine formula.
+func DistanceMiles(lat1, lon1, lat2, lon2 float64) float64 {
+	rlat1, rlat2 := radians(lat1), radians(lat2)
+	dlat := radians(lat2 - lat1)
+	dlon := radians(lon2 - lon1)
+
+	a := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(rlat1)*math.Cos(rlat2)*math.Sin(dlon/2)*math.Sin(dlon/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// InitialBearing returns the bearing to travel from the first coordinate
+// towards the second along a great circle.
+func InitialBearing(lat1, lon1, lat2, lon2 float64) Direction {
+	rlat1, rlat2 := radians(lat1), radians(lat2)
+	dlon := radians(lon2 - lon1)
+
+	y := math.Sin(dlon) * math.Cos(rlat2)
+	x := math.Cos(rlat1)*math.Sin(rlat2) - math.Sin(rlat1)*math.Cos(rlat2)*math.Cos(dlon)
+	return Direction(normalizeDegrees(degrees(math.Atan2(y, x))))
+}
+
+// Destination returns the coordinate reached by travelling the given distance
+// from a coordinate along a great circle with the given initial bearing.
+func Destination(lat, lon float64, bearing Direction, miles float64) (float64, float64) {
+	rlat, rlon := radians(lat), radians(lon)
+	brg := radians(bearing.Degrees())
+	d := miles / earthRadiusMiles
+
+	lat2 := math.Asin(math.Sin(rlat)*math.Cos(d) + math.Cos(rlat)*math.Sin(d)*math.Cos(brg))
+	lon2 := rlon + math.Atan2(math.Sin(brg)*math.Sin(d)*math.Cos(rlat), math.Cos(d)-math.Sin(rlat)*math.Sin(lat2))
+	return degrees(lat2), math.Mod(degrees(lon2)+540, 360) - 180
+}
+
+// DistanceTo returns the great-circle distance in miles from s to a
+// coordinate.
+func (s *Spot) DistanceTo(lat, lon float64) float64 {
+	return DistanceMiles(float64(s.Latitude), float64(s.Longitude), lat, lon)
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,92 @@
+package spot
+
+import (
+	"encoding/json"
+
+	"google.golang.org/adk/tool"
+)
+
+type AddSpotArgs struct {
+	Spot Spot `json:"spot" jsonschema_description:"The new spot to add to the watch list. Its name must not already be in use."`
+
+	// missingFacing is set when the decoded spot omitted facing.
+	missingFacing error
+}
+
+type UpdateSpotArgs struct {
+	Name string `json:"name" jsonschema_description:"The current name of the spot to update."`
+	Spot Spot   `json:"spot" jsonschema_description:"The full replacement spot. Fetch the existing spot with get_spots_of_interest first and change only the fields that need updating."`
+
+	// missingFacing is set when the decoded spot omitted facing.
+	missingFacing error
+}
+
+func (a *AddSpotArgs) UnmarshalJSON(b []byte) error {
+	type plain AddSpotArgs
+	if err := json.Unmarshal(b, (*plain)(a)); err != nil {
+		return err
+	}
+	a.missingFacing = spotArgFacing(b, a.Spot)
+	return nil
+}
+
+func (a *UpdateSpotArgs) UnmarshalJSON(b []byte) error {
+	type plain UpdateSpotArgs
+	if err := json.Unmarshal(b, (*plain)(a)); err != nil {
+		return err
+	}
+	a.missingFacing = spotArgFacing(b, a.Spot)
+	return nil
+}
+
+// spotArgFacing applies the spots file's facing check to the "spot" argument
+// of a tool call, so a spot added from chat can't default to north either.
+func spotArgFacing(b []byte, s Spot) error {
+	var raw struct {
+		Spot json.RawMessage `json:"spot"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw.Spot) == 0 {
+		raw.Spot = json.RawMessage("null")
+	}
+	return requireFacing([]byte("["+string(raw.Spot)+"]"), []Spot{s})
+}
+
+type RemoveSpotArgs struct {
+	Name string `json:"name" jsonschema_description:"The name of the spot to remove from the watch list."`
+}
+
+// AddSpot validates the provided spot, which must set facing, appends it to the default Registry and
+// persists the watch list. Returns the updated watch list.
+func AddSpot(_ tool.Context, args AddSpotArgs) (SpotsResult, error) {
+	if args.missingFacing != nil {
+		return SpotsResult{}, args.missingFacing
+	}
+	if err := defaultRegistry.Add(args.Spot); err != nil {
+		return SpotsResult{}, err
+	}
+	return SpotsResult{Spots: defaultRegistry.Spots()}, nil
+}
+
+// UpdateSpot replaces the named spot in the default Registry and persists the
+// watch list. Returns the updated watch list.
+func UpdateSpot(_ tool.Context, args UpdateSpotArgs) (SpotsResult, error) {
+	if args.missingFacing != nil {
+		return SpotsResult{}, args.missingFacing
+	}
+	if err := defaultRegistry.Update(args.Name, args.Spot); err != nil {
+		return SpotsResult{}, err
+	}
+	return SpotsResult{Spots: defaultRegistry.Spots()}, nil
+}
+
+// RemoveSpot deletes the named spot from the default Registry and persists the
+// watch list. Returns the updated watch list.
+func RemoveSpot(_ tool.Context, args RemoveSpotArgs) (SpotsResult, error) {
+	if err := defaultRegistry.Remove(args.Name); err != nil {
+		return SpotsResult{}, err
+	}
+	return SpotsResult{Spots: defaultRegistry.Spots()}, nil
+}
@@ -0,0 +1,222 @@
+package spot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// maxSuggestions caps the number of near-miss names returned with a
+// NotFoundError.
+const maxSuggestions = 3
+
+// minSubstringLen is the shortest query matched anywhere inside a name, so a
+// stray letter doesn't match every spot.
+const minSubstringLen = 3
+
+// abbreviations expands common shorthand so "stoney pt" matches "Stoney Point".
+var abbreviations = map[string]string{
+	"pt":  "point",
+	"pnt": "point",
+	"bch": "beach",
+	"hbr": "harbor",
+	"is":  "island",
+	"mt":  "mount",
+	"ft":  "fort",
+	"st":  "saint",
+}
+
+// NotFoundError is returned when no spot matches the requested name. It wraps
+// ErrInvalidName and carries the closest names so the agent can retry.
+type NotFoundError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("%v: %q", ErrInvalidName, e.Name)
+	}
+	return fmt.Sprintf("%v: %q, did you mean %s?", ErrInvalidName, e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrInvalidName
+}
+
+// matchTier ranks how well a query matched a spot. Lower is better.
+type matchTier int
+
+const (
+	tierExact matchTier = iota
+	tierWords
+	tierPartial
+	tierFuzzy
+	tierNone
+)
+
+// MatchName returns the spots best matching name. An exact name or alias match
+// wins, then spots whose name contains every word of the query (so "Rincon"
+// finds "Rincon Point"), then partial names where every query word starts a
+// word of the name or the query appears inside it (so "emp" finds "Empire
+// Beach"), then typo-tolerant matches. When nothing matches, the
+// error is a *NotFoundError with suggestions.
+func MatchName(spots []Spot, name string) ([]Spot, error) {
+	query := normalizeName(name)
+
+	best := tierNone
+	var matched []Spot
+	for _, s := range spots {
+		tier := matchSpot(s, query)
+		switch {
+		case tier < best:
+			best, matched = tier, []Spot{s}
+		case tier == best && tier != tierNone:
+			matched = append(matched, s)
+		}
+	}
+
+	if best == tierNone {
+		return nil, &NotFoundError{Name: name, Suggestions: suggest(spots, query)}
+	}
+	return matched, nil
+}
+
+func matchSpot(s Spot, query string) matchTier {
+	best := tierNone
+	for _, candidate := range spotNames(s) {
+		switch {
+		case candidate == query:
+			return tierExact
+		case containsWords(candidate, query):
+			best = min(best, tierWords)
+		case containsPrefixes(candidate, query), len(query) >= minSubstringLen && strings.Contains(candidate, query):
+			best = min(best, tierPartial)
+		case fuzzyDistance(candidate, query) <= maxTypos(query):
+			best = min(best, tierFuzzy)
+		}
+	}
+	return best
+}
+
+// suggest returns up to maxSuggestions spot names closest to query, skipping
+// anything too far off to be a plausible typo.
+func suggest(spots []Spot, query string) []string {
+	type scored struct {
+		name string
+		dist int
+	}
+
+	var candidates []scored
+	for _, s := range spots {
+		dist := -1
+		for _, n := range spotNames(s) {
+			if d := fuzzyDistance(n, query); dist < 0 || d < dist {
+				dist = d
+			}
+		}
+		if dist >= 0 && dist <= len(query)/2 {
+			candidates = append(candidates, scored{name: s.Name, dist: dist})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b scored) int { return a.dist - b.dist })
+	names := make([]string, 0, maxSuggestions)
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// spotNames returns the normalized name and aliases of s.
+func spotNames(s Spot) []string {
+	names := make([]string, 0, len(s.Aliases)+1)
+	names = append(names, normalizeName(s.Name))
+	for _, a := range s.Aliases {
+		names = append(names, normalizeName(a))
+	}
+	return names
+}
+
+// normalizeName lowercases name, strips punctuation and expands common
+// abbreviations.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		if full, ok := abbreviations[w]; ok {
+			words[i] = full
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// containsWords reports whether every word of query appears in candidate.
+func containsWords(candidate, query string) bool {
+	if query == "" {
+		return false
+	}
+	words := strings.Fields(candidate)
+	for _, q := range strings.Fields(query) {
+		if !slices.Contains(words, q) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsPrefixes reports whether every word of query starts a word of
+// candidate.
+func containsPrefixes(candidate, query string) bool {
+	if query == "" {
+		return false
+	}
+	words := strings.Fields(candidate)
+	for _, q := range strings.Fields(query) {
+		if !slices.ContainsFunc(words, func(w string) bool { return strings.HasPrefix(w, q) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyDistance is the edit distance between query and candidate, also trying
+// just the leading words of candidate so "rincn" is close to "rincon point".
+func fuzzyDistance(candidate, query string) int {
+	dist := levenshtein(candidate, query)
+
+	words := strings.Fields(candidate)
+	if n := len(strings.Fields(query)); n < len(words) {
+		dist = min(dist, levenshtein(strings.Join(words[:n], " "), query))
+	}
+	return dist
+}
+
+// maxTypos is the edit distance still treated as a match for query.
+func maxTypos(query string) int {
+	return max(1, len(query)/5)
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,63 @@
+package spot
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"google.golang.org/adk/tool"
+)
+
+var ErrInvalidRadius = errors.New("radius must be greater than zero")
+
+type NearbyArgs struct {
+	Latitude    float64 `json:"latitude" jsonschema_description:"Latitude of the search origin in decimal degrees."`
+	Longitude   float64 `json:"longitude" jsonschema_description:"Longitude of the search origin in decimal degrees."`
+	RadiusMiles float64 `json:"radius_miles" jsonschema_description:"Search radius in statute miles."`
+}
+
+// NearbySpot is a spot found by FindSpotsNear along with where it lies
+// relative to the search origin.
+type NearbySpot struct {
+	Spot          Spot    `json:"spot"`
+	DistanceMiles float64 `json:"distance_miles" jsonschema_description:"Great-circle distance from the search origin in statute miles."`
+	BearingDeg    float64 `json:"bearing_deg" jsonschema_description:"Initial bearing from the search origin to the spot in degrees true."`
+	Bearing       string  `json:"bearing" jsonschema_description:"Bearing as a 16-point compass abbreviation, e.g. 'NE'."`
+}
+
+type NearbyResult struct {
+	Spots []NearbySpot `json:"spots" jsonschema_description:"Spots within the radius, nearest first. Empty when none are in range."`
+}
+
+// FindSpotsNear returns every spot in the default Registry within the radius
+// of a coordinate, sorted by great-circle distance.
+func FindSpotsNear(_ tool.Context, args NearbyArgs) (NearbyResult, error) {
+	if args.RadiusMiles <= 0 {
+		return NearbyResult{}, ErrInvalidRadius
+	}
+	if args.Latitude < -90 || args.Latitude > 90 || args.Longitude < -180 || args.Longitude > 180 {
+		return NearbyResult{}, ErrOutOfRange
+	}
+
+	nearby := []NearbySpot{}
+	for _, s := range defaultRegistry.Spots() {
+		dist := DistanceMiles(args.Latitude, args.Longitude, float64(s.Latitude), float64(s.Longitude))
+		if dist > args.RadiusMiles {
+			continue
+		}
+
+		bearing := InitialBearing(args.Latitude, args.Longitude, float64(s.Latitude), float64(s.Longitude))
+		nearby = append(nearby, NearbySpot{
+			Spot:          s,
+			DistanceMiles: math.Round(dist*10) / 10,
+			BearingDeg:    math.Round(bearing.Degrees()),
+			Bearing:       bearing.Compass(),
+		})
+	}
+
+	slices.SortFunc(nearby, func(a, b NearbySpot) int {
+		return cmp.Compare(a.DistanceMiles, b.DistanceMiles)
+	})
+	return NearbyResult{Spots: nearby}, nil
+}
@@ -0,0 +1,61 @@
+package spot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDistanceAndBearing(t *testing.T) {
+	// Duluth, MN to Minneapolis, MN is roughly 137 miles to the SSW.
+	dist := DistanceMiles(46.7867, -92.1005, 44.9778, -93.2650)
+	if math.Abs(dist-137) > 3 {
+		t.Fatalf("Expected roughly 137 miles, got %.1f", dist)
+	}
+	if b := InitialBearing(46.7867, -92.1005, 44.9778, -93.2650); b.Compass() != "SSW" {
+		t.Fatalf("Expected bearing SSW, got %s", b)
+	}
+}
+
+func TestFindSpotsNear(t *testing.T) {
+	testCases := []struct {
+		name      string
+		args      NearbyArgs
+		expected  error
+		returnLen int
+	}{
+		{
+			name:      "Duluth 50mi",
+			args:      NearbyArgs{Latitude: 46.7867, Longitude: -92.1005, RadiusMiles: 50},
+			returnLen: 1,
+		},
+		{
+			name:      "Santa Barbara 250mi",
+			args:      NearbyArgs{Latitude: 34.4208, Longitude: -119.6982, RadiusMiles: 250},
+			returnLen: 2,
+		},
+		{
+			name:     "Zero radius",
+			args:     NearbyArgs{Latitude: 34.4208, Longitude: -119.6982},
+			expected: ErrInvalidRadius,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("Near %s", tt.name), func(t *testing.T) {
+			result, err := FindSpotsNear(nil, tt.args)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, tt.expected)
+			}
+			if sl := len(result.Spots); sl != tt.returnLen {
+				t.Fatalf("Expected %d returned spots, got %d", tt.returnLen, sl)
+			}
+			for i := 1; i < len(result.Spots); i++ {
+				if result.Spots[i-1].DistanceMiles > result.Spots[i].DistanceMiles {
+					t.Fatalf("spots not sorted by distance: %+v", result.Spots)
+				}
+			}
+		})
+	}
+}
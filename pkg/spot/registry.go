@@ -0,0 +1,607 @@
+package spot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpotsFileEnv names the environment variable pointing at a YAML or JSON spot
+// watch list. The -spots flag takes precedence; when neither is set, the
+// embedded default list is served.
+const SpotsFileEnv = "WAVE_SPOTS_FILE"
+
+// DefaultReloadInterval is how often a watched spots file is checked for
+// changes.
+const DefaultReloadInterval = 5 * time.Second
+
+var (
+	ErrDuplicateName = errors.New("duplicate spot name")
+	ErrEmptySpots    = errors.New("spot file contains no spots")
+	ErrUnknownFormat = errors.New("unknown spot file format, expected .yaml, .yml or .json")
+	ErrNoSpotStore   = errors.New("no user config directory to save spot edits to")
+)
+
+// spotFile is the on-disk shape of a spots file. A bare list of spots is also
+// accepted.
+type spotFile struct {
+	Spots []Spot `json:"spots"`
+}
+
+// spotEdits is the on-disk shape of the edit store: the spots added or changed
+// from chat, matched to the base list by name, and the names removed from it.
+type spotEdits struct {
+	Spots   []Spot   `json:"spots"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Registry holds the active spot watch list. It is safe for concurrent use and
+// can be swapped out underneath running tools when the backing file changes.
+type Registry struct {
+	mu      sync.RWMutex
+	base    []Spot
+	spots   []Spot
+	path    string
+	modTime time.Time
+
+	// store is the file spots added, updated or removed from chat are saved
+	// to. Its edits are merged over base, the embedded list or the loaded
+	// spots file, which is never rewritten, so later changes to spots the
+	// user hasn't edited still show through.
+	store     string
+	edits     spotEdits
+	storeTime time.Time
+}
+
+// NewRegistry returns a Registry serving the provided spots.
+func NewRegistry(spots []Spot) *Registry {
+	return &Registry{base: spots, spots: spots}
+}
+
+var defaultRegistry = func() *Registry {
+	r := NewRegistry(spots)
+	r.store = defaultStorePath()
+	return r
+}()
+
+// defaultStorePath is where spots edited from chat are saved:
+// spot_edits.yaml in the user config directory.
+func defaultStorePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wave-report-agent", "spot_edits.yaml")
+}
+
+// Default returns the process-wide Registry used by the spot tools.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Spots returns a copy of the spots currently in the registry.
+func (r *Registry) Spots() []Spot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Spot, len(r.spots))
+	copy(out, r.spots)
+	return out
+}
+
+// Path returns the spots file the registry's base list was loaded from, or an
+// empty string when it is the embedded list.
+func (r *Registry) Path() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.path
+}
+
+// Replace validates spots and swaps them in as the base list, with any edits
+// from the store merged over them.
+func (r *Registry) Replace(spots []Spot) error {
+	if err := validateSpots(spots); err != nil {
+		return err
+	}
+	normalizeSpots(spots)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := applyEdits(spots, r.edits)
+	if err := validateSpots(merged); err != nil {
+		return fmt.Errorf("applying spot edits from %s: %w", r.store, err)
+	}
+	r.base, r.spots = spots, merged
+	return nil
+}
+
+// Add appends a new spot to the watch list and persists it to the edit
+// store.
+func (r *Registry) Add(s Spot) error {
+	return r.mutate(func(spots []Spot) ([]Spot, error) {
+		if i := indexOf(spots, s.Name); i >= 0 {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateName, s.Name)
+		}
+		return append(spots, s), nil
+	})
+}
+
+// Update replaces the spot called name and persists the change to the edit
+// store. The replacement may rename the spot.
+func (r *Registry) Update(name string, s Spot) error {
+	return r.mutate(func(spots []Spot) ([]Spot, error) {
+		i := indexOf(spots, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidName, name)
+		}
+		spots[i] = s
+		return spots, nil
+	})
+}
+
+// Remove deletes the spot called name and persists the change to the edit
+// store.
+func (r *Registry) Remove(name string) error {
+	return r.mutate(func(spots []Spot) ([]Spot, error) {
+		i := indexOf(spots, name)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidName, name)
+		}
+		return append(spots[:i], spots[i+1:]...), nil
+	})
+}
+
+// mutate applies change to a copy of the watch list, validates the result,
+// writes its difference from the base list to the edit store and only then
+// swaps it in. The write lock is held throughout so concurrent edits can't
+// interleave.
+func (r *Registry) mutate(change func([]Spot) ([]Spot, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.store == "" {
+		return ErrNoSpotStore
+	}
+
+	spots := make([]Spot, len(r.spots))
+	copy(spots, r.spots)
+	spots, err := change(spots)
+	if err != nil {
+		return err
+	}
+	if err := validateSpots(spots); err != nil {
+		return err
+	}
+	normalizeSpots(spots)
+
+	edits := diffEdits(r.base, spots)
+	modTime, err := writeFile(r.store, edits)
+	if err != nil {
+		return err
+	}
+	r.edits, r.storeTime = edits, modTime
+	r.spots = applyEdits(r.base, edits)
+	return nil
+}
+
+// LoadFile reads and validates the spots file at path and makes it the active
+// watch list. The registry remembers the path so Watch can reload it.
+func (r *Registry) LoadFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	spots, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Replace(spots); err != nil {
+		return fmt.Errorf("validating %s: %w", path, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.path = path
+	r.modTime = info.ModTime()
+	return nil
+}
+
+// Watch polls the registry's backing file every interval and reloads it when
+// its modification time changes. A file that fails to parse or validate is
+// logged and the previous watch list is kept. Watch blocks until ctx is done.
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.reloadIfChanged(); err != nil {
+				log.Printf("keeping previous spots, reload of %s failed: %v", r.Path(), err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the spots file or the edit store when its
+// modification time differs from the last successful load. Reports whether a
+// reload happened.
+func (r *Registry) reloadIfChanged() (bool, error) {
+	r.mu.RLock()
+	path, last := r.path, r.modTime
+	store, storeLast := r.store, r.storeTime
+	r.mu.RUnlock()
+
+	reloaded := false
+	if changed, err := fileChanged(path, last); err != nil {
+		return false, err
+	} else if changed {
+		if err := r.LoadFile(path); err != nil {
+			return false, err
+		}
+		reloaded = true
+	}
+
+	if changed, err := fileChanged(store, storeLast); err != nil {
+		return reloaded, err
+	} else if changed {
+		if err := r.loadStore(); err != nil {
+			return reloaded, err
+		}
+		reloaded = true
+	}
+	return reloaded, nil
+}
+
+// fileChanged reports whether the file at path has a different modification
+// time than last. A missing file has changed only if it was loaded before.
+func fileChanged(path string, last time.Time) (bool, error) {
+	if path == "" {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return !last.IsZero(), nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !info.ModTime().Equal(last), nil
+}
+
+// loadStore reads the edit store and merges it over the base list. A missing
+// store clears any previous edits.
+func (r *Registry) loadStore() error {
+	var (
+		edits   spotEdits
+		modTime time.Time
+	)
+	info, err := os.Stat(r.store)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if edits, err = readEdits(r.store); err != nil {
+			return err
+		}
+		modTime = info.ModTime()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := applyEdits(r.base, edits)
+	if err := validateSpots(merged); err != nil {
+		return fmt.Errorf("applying spot edits from %s: %w", r.store, err)
+	}
+	normalizeSpots(merged)
+	r.edits, r.storeTime, r.spots = edits, modTime, merged
+	return nil
+}
+
+// Load loads the spots file at path into the default registry, merges the
+// spots edited from chat over it and starts watching both for changes. An
+// empty path falls back to SpotsFileEnv and then to the embedded list.
+func Load(ctx context.Context, path string) error {
+	if path == "" {
+		path = os.Getenv(SpotsFileEnv)
+	}
+	if path != "" {
+		if err := defaultRegistry.LoadFile(path); err != nil {
+			return fmt.Errorf("loading %s: %w", path, err)
+		}
+	}
+	if defaultRegistry.store != "" {
+		if err := defaultRegistry.loadStore(); err != nil {
+			return err
+		}
+	}
+
+	if e := defaultRegistry.edits; len(e.Spots) > 0 || len(e.Removed) > 0 {
+		base := path
+		if base == "" {
+			base = "the embedded spot list"
+		}
+		log.Printf("Applying spot edits from %s over %s: %d added or changed, %d removed", defaultRegistry.store, base, len(e.Spots), len(e.Removed))
+	}
+	go defaultRegistry.Watch(ctx, DefaultReloadInterval)
+	return nil
+}
+
+// ReadFile parses a YAML or JSON spots file, chosen by extension. The file may
+// either be a list of spots or an object with a top-level "spots" key. YAML
+// documents use the same field names as the JSON encoding of Spot.
+func ReadFile(path string) ([]Spot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		if b, err = yamlToJSON(b); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	spots, err := decodeSpots(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := requireFacing(b, spots); err != nil {
+		return nil, fmt.Errorf("validating %s: %w", path, err)
+	}
+	return spots, nil
+}
+
+// writeFile encodes v in the format matching path's extension and atomically
+// replaces the file. Returns the new modification time.
+func writeFile(path string, v any) (time.Time, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		b = append(b, '\n')
+	case ".yaml", ".yml":
+		if b, err = jsonToYAML(b); err != nil {
+			return time.Time{}, err
+		}
+	default:
+		return time.Time{}, ErrUnknownFormat
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return time.Time{}, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return time.Time{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return time.Time{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return time.Time{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// decodeSpots decodes either a bare list of spots or a spotFile object,
+// chosen by the document's shape so a bad field in a "spots:" file reports its
+// own error rather than one about the document not being a list.
+func decodeSpots(b []byte) ([]Spot, error) {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '[' {
+		var list []Spot
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+
+	var f spotFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	return f.Spots, nil
+}
+
+// readEdits parses the YAML or JSON edit store at path.
+func readEdits(path string) (spotEdits, error) {
+	var e spotEdits
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return e, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		if b, err = yamlToJSON(b); err != nil {
+			return e, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	default:
+		return e, ErrUnknownFormat
+	}
+
+	if err := json.Unmarshal(b, &e); err != nil {
+		return e, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := requireFacing(b, e.Spots); err != nil {
+		return e, fmt.Errorf("validating %s: %w", path, err)
+	}
+	return e, nil
+}
+
+// applyEdits returns base with the edits merged over it: removed names are
+// dropped, and each edited spot replaces the base spot of the same name or is
+// appended.
+func applyEdits(base []Spot, e spotEdits) []Spot {
+	out := make([]Spot, 0, len(base)+len(e.Spots))
+	for _, s := range base {
+		if !slices.ContainsFunc(e.Removed, func(name string) bool { return strings.EqualFold(name, s.Name) }) {
+			out = append(out, s)
+		}
+	}
+	for _, s := range e.Spots {
+		if i := indexOf(out, s.Name); i >= 0 {
+			out[i] = s
+		} else {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// diffEdits returns the edits that turn base into spots, so only the spots
+// changed from chat are stored.
+func diffEdits(base, spots []Spot) spotEdits {
+	e := spotEdits{Spots: []Spot{}}
+	for _, s := range base {
+		if indexOf(spots, s.Name) < 0 {
+			e.Removed = append(e.Removed, s.Name)
+		}
+	}
+	for _, s := range spots {
+		if i := indexOf(base, s.Name); i < 0 || !reflect.DeepEqual(base[i], s) {
+			e.Spots = append(e.Spots, s)
+		}
+	}
+	return e
+}
+
+// requireFacing reports every spot in the decoded file b that omits "facing".
+// A missing facing would decode as 0° (north) and pass validation, yet wind
+// direction, shadowing, the seaward marine offset and current components all
+// depend on it.
+func requireFacing(b []byte, spots []Spot) error {
+	var f struct {
+		Spots []map[string]json.RawMessage `json:"spots"`
+	}
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(b, &f); err == nil {
+		raw = f.Spots
+	} else if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, fields := range raw {
+		if v, ok := fields["facing"]; ok && string(v) != "null" {
+			continue
+		}
+		name := ""
+		if i < len(spots) {
+			name = spots[i].Name
+		}
+		errs = append(errs, &ValidationError{Spot: name, Fields: []*FieldError{{Field: "facing", Err: ErrRequired}}})
+	}
+	return errors.Join(errs...)
+}
+
+// yamlToJSON converts a YAML document to JSON so spots decode through their
+// json tags and custom unmarshalers regardless of the file format.
+func yamlToJSON(b []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// jsonToYAML re-encodes a JSON document as block-style YAML, keeping the field
+// order of the JSON encoding.
+func jsonToYAML(b []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+// clearStyle drops the flow style yaml.v3 assigns to JSON input so the output
+// is written as ordinary block YAML.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// indexOf returns the index of the spot called name, ignoring case, or -1.
+func indexOf(spots []Spot, name string) int {
+	for i := range spots {
+		if strings.EqualFold(spots[i].Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeSpots fills in zero values that would otherwise encode as null.
+func normalizeSpots(spots []Spot) {
+	for i := range spots {
+		if spots[i].Meta == nil {
+			spots[i].Meta = map[string]any{}
+		}
+	}
+}
+
+// validateSpots checks the watch list as a whole and returns every problem
+// found.
+func validateSpots(spots []Spot) error {
+	if len(spots) == 0 {
+		return ErrEmptySpots
+	}
+
+	var errs []error
+	seen := make(map[string]bool, len(spots))
+	for i := range spots {
+		if err := spots[i].Validate(); err != nil {
+			errs = append(errs, err)
+		}
+
+		key := strings.ToLower(spots[i].Name)
+		if key == "" {
+			continue
+		}
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicateName, spots[i].Name))
+		}
+		seen[key] = true
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,272 @@
+package spot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+const yamlSpots = `
+spots:
+  - name: Stinson Beach
+    city: Stinson Beach
+    state: California
+    latitude: 37.9
+    longitude: -122.64
+    spot_type: ocean
+    break_type: beach break
+    facing: SW
+    nearest_buoy_id: "46026"
+`
+
+const jsonSpots = `[
+  {"name": "Stinson Beach", "spot_type": "ocean", "break_type": "beach break", "facing": "SW", "latitude": 37.9, "longitude": -122.64},
+  {"name": "Bolinas", "spot_type": "ocean", "break_type": "beach break", "facing": 180, "latitude": 37.9, "longitude": -122.68}
+]`
+
+const bolinas = `[{"name": "Bolinas", "spot_type": "ocean", "break_type": "beach break", "facing": 180, "latitude": 37.9, "longitude": -122.68}]`
+
+func writeSpotsFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing spots file: %v", err)
+	}
+	return path
+}
+
+func TestReadFile(t *testing.T) {
+	testCases := []struct {
+		file      string
+		contents  string
+		expected  error
+		returnLen int
+	}{
+		{file: "spots.yaml", contents: yamlSpots, returnLen: 1},
+		{file: "spots.json", contents: jsonSpots, returnLen: 2},
+		{file: "spots.toml", contents: "", expected: ErrUnknownFormat},
+		// The spots: file's own error surfaces, not one about it not being a list.
+		{file: "bad-facing.yaml", contents: "spots:\n  - name: Stinson Beach\n    facing: sideways\n", expected: ErrInvalidDirection},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("File %s", tt.file), func(t *testing.T) {
+			spots, err := ReadFile(writeSpotsFile(t, tt.file, tt.contents))
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, tt.expected)
+			}
+
+			if sl := len(spots); sl != tt.returnLen {
+				t.Fatalf("Expected %d spots, got %d", tt.returnLen, sl)
+			}
+		})
+	}
+}
+
+func TestRegistryLoadFile(t *testing.T) {
+	r := NewRegistry(spots)
+	dup := `[
+  {"name": "Bolinas", "spot_type": "ocean", "break_type": "beach break", "facing": 180, "latitude": 37.9, "longitude": -122.68},
+  {"name": "bolinas", "spot_type": "ocean", "break_type": "beach break", "facing": 180, "latitude": 37.9, "longitude": -122.68}
+]`
+	if err := r.LoadFile(writeSpotsFile(t, "dup.json", dup)); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+	if sl := len(r.Spots()); sl != len(spots) {
+		t.Fatalf("failed load should keep the previous %d spots, got %d", len(spots), sl)
+	}
+
+	if err := r.LoadFile(writeSpotsFile(t, "spots.yaml", yamlSpots)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.Spots()[0].Name; got != "Stinson Beach" {
+		t.Fatalf("expected Stinson Beach, got %s", got)
+	}
+}
+
+func TestRegistryReload(t *testing.T) {
+	path := writeSpotsFile(t, "spots.json", jsonSpots)
+	r := NewRegistry(nil)
+	if err := r.LoadFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if reloaded, err := r.reloadIfChanged(); reloaded || err != nil {
+		t.Fatalf("expected no reload for an unchanged file, got reloaded=%v err=%v", reloaded, err)
+	}
+
+	if err := os.WriteFile(path, []byte(bolinas), 0o644); err != nil {
+		t.Fatalf("rewriting spots file: %v", err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("touching spots file: %v", err)
+	}
+
+	if reloaded, err := r.reloadIfChanged(); !reloaded || err != nil {
+		t.Fatalf("expected reload after change, got reloaded=%v err=%v", reloaded, err)
+	}
+	if sl := len(r.Spots()); sl != 1 {
+		t.Fatalf("Expected 1 spot after reload, got %d", sl)
+	}
+}
+
+func TestRegistryMutations(t *testing.T) {
+	path := writeSpotsFile(t, "spots.yaml", yamlSpots)
+	r := NewRegistry(nil)
+	r.store = filepath.Join(t.TempDir(), "spot_edits.yaml")
+	if err := r.LoadFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bolinas := Spot{
+		Name:          "Bolinas",
+		Latitude:      37.9,
+		Longitude:     -122.68,
+		SpotType:      SpotTypeOcean,
+		BreakType:     BreakTypeBeach,
+		Facing:        180,
+		NearestBuoyID: "46026",
+	}
+	if err := r.Add(bolinas); err != nil {
+		t.Fatalf("unexpected error adding spot: %v", err)
+	}
+	if err := r.Add(bolinas); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+
+	bolinas.NearestBuoyID = "bad"
+	var verr *ValidationError
+	if err := r.Update("bolinas", bolinas); !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	bolinas.NearestBuoyID = "46214"
+	if err := r.Update("bolinas", bolinas); err != nil {
+		t.Fatalf("unexpected error updating spot: %v", err)
+	}
+	if err := r.Remove("Stinson Beach"); err != nil {
+		t.Fatalf("unexpected error removing spot: %v", err)
+	}
+	if err := r.Remove("Stinson Beach"); !errors.Is(err, ErrInvalidName) {
+		t.Fatalf("expected invalid name error, got %v", err)
+	}
+
+	got := r.Spots()
+	if len(got) != 1 || got[0].Name != "Bolinas" || got[0].NearestBuoyID != "46214" {
+		t.Fatalf("Expected only the updated Bolinas spot, got %+v", got)
+	}
+
+	// The spots file the user passed in is left untouched.
+	persisted, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("re-reading spots file: %v", err)
+	}
+	if len(persisted) != 1 || persisted[0].Name != "Stinson Beach" {
+		t.Fatalf("Expected the spots file to be unchanged, got %+v", persisted)
+	}
+
+	// A new run merges the stored edits over the same file.
+	reloaded := NewRegistry(nil)
+	reloaded.store = r.store
+	if err := reloaded.LoadFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reloaded.loadStore(); err != nil {
+		t.Fatalf("unexpected error loading edits: %v", err)
+	}
+	if got := reloaded.Spots(); len(got) != 1 || got[0].Name != "Bolinas" || got[0].NearestBuoyID != "46214" {
+		t.Fatalf("Expected the stored edits to be merged over the spots file, got %+v", got)
+	}
+
+	if err := NewRegistry(spots).Add(bolinas); !errors.Is(err, ErrNoSpotStore) {
+		t.Fatalf("expected no spot store error, got %v", err)
+	}
+}
+
+func TestRegistryEditsMergeOverEmbeddedList(t *testing.T) {
+	r := NewRegistry(spots)
+	r.store = filepath.Join(t.TempDir(), "wave-report-agent", "spot_edits.yaml")
+
+	bolinas := Spot{
+		Name:      "Bolinas",
+		Latitude:  37.9,
+		Longitude: -122.68,
+		SpotType:  SpotTypeOcean,
+		BreakType: BreakTypeBeach,
+		Facing:    180,
+	}
+	if err := r.Add(bolinas); err != nil {
+		t.Fatalf("unexpected error adding spot to the embedded list: %v", err)
+	}
+	if err := r.Remove(spots[1].Name); err != nil {
+		t.Fatalf("unexpected error removing spot: %v", err)
+	}
+
+	edits, err := readEdits(r.store)
+	if err != nil {
+		t.Fatalf("re-reading edit store: %v", err)
+	}
+	if len(edits.Spots) != 1 || edits.Spots[0].Name != "Bolinas" || !slices.Equal(edits.Removed, []string{spots[1].Name}) {
+		t.Fatalf("Expected only Bolinas and the removal in the store, got %+v", edits)
+	}
+
+	// A later change to the embedded list still reaches spots that were never
+	// edited from chat.
+	updated := slices.Clone(spots)
+	updated[0].Spec = "updated upstream"
+	next := NewRegistry(updated)
+	next.store = r.store
+	if err := next.loadStore(); err != nil {
+		t.Fatalf("unexpected error loading edits: %v", err)
+	}
+	got := next.Spots()
+	if len(got) != len(spots) || got[0].Spec != "updated upstream" || got[len(got)-1].Name != "Bolinas" || indexOf(got, spots[1].Name) >= 0 {
+		t.Fatalf("Expected the edits merged over the updated embedded list, got %d spots", len(got))
+	}
+}
+
+func TestManageSpotRequiresFacing(t *testing.T) {
+	const noFacing = `{"name": "Bolinas", "spot_type": "ocean", "break_type": "beach break", "latitude": 37.9, "longitude": -122.68}`
+
+	testCases := []struct {
+		name string
+		run  func(b []byte) error
+	}{
+		{name: "add_spot", run: func(b []byte) error {
+			var args AddSpotArgs
+			if err := json.Unmarshal(b, &args); err != nil {
+				return err
+			}
+			_, err := AddSpot(nil, args)
+			return err
+		}},
+		{name: "update_spot", run: func(b []byte) error {
+			var args UpdateSpotArgs
+			if err := json.Unmarshal(b, &args); err != nil {
+				return err
+			}
+			_, err := UpdateSpot(nil, args)
+			return err
+		}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			before := Default().Spots()
+			err := tt.run([]byte(`{"name": "Ocean Beach", "spot": ` + noFacing + `}`))
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrRequired) || verr.Fields[0].Field != "facing" {
+				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: facing %v", err, ErrRequired)
+			}
+			if after := Default().Spots(); len(after) != len(before) {
+				t.Fatalf("expected the watch list to be unchanged, got %d spots", len(after))
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package spot
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// fullSwellAngle is the largest angle between the swell direction and the
+	// spot's facing at which the full swell reaches the beach.
+	fullSwellAngle = 45.0
+
+	// maxSwellAngle is the angle off the spot's facing at which the coastline
+	// blocks all swell. Between fullSwellAngle and maxSwellAngle the swell
+	// wraps in with steadily less energy.
+	maxSwellAngle = 135.0
+
+	// optimalSwellFloor is the least energy factor given to a swell inside
+	// one of the spot's optimal swell sectors, for spots whose best swell
+	// wraps in from further off their facing than the coastline taper allows.
+	optimalSwellFloor = 0.5
+
+	// optimalSwellTaper is how far outside an optimal swell sector, in
+	// degrees, the floor fades out.
+	optimalSwellTaper = 45.0
+)
+
+// Shadow is a sector of swell directions that is blocked or weakened before it
+// reaches the spot, e.g. by an island chain or headland.
+type Shadow struct {
+	Name        string       `json:"name" jsonschema_description:"What casts the shadow, e.g. 'Channel Islands'."`
+	Sector      DirectionArc `json:"sector" jsonschema_description:"Swell directions (degrees true, where swell comes FROM) affected by the shadow."`
+	Attenuation float64      `json:"attenuation" jsonschema_description:"Fraction of swell energy removed, from 0 (no effect) to 1 (fully blocked)."`
+}
+
+// SwellExposure is the share of a swell that reaches a spot after shadowing.
+type SwellExposure struct {
+	// EnergyFactor is the fraction of swell energy that reaches the spot, from
+	// 0 to 1.
+	EnergyFactor float64
+	// HeightFactor is the matching fraction of swell height. Wave energy scales
+	// with height squared, so this is the square root of EnergyFactor.
+	HeightFactor float64
+	// BlockedBy names the shadows, or the coastline itself, that reduced the
+	// swell. Empty when the swell arrives unobstructed.
+	BlockedBy []string
+}
+
+// Exposure returns how much of a swell arriving from dir reaches the spot.
+// The coastline tapers swell energy smoothly from full strength within 45° of
+// the spot's facing to nothing 135° off it. The spot's optimal swell sectors
+// raise that to at least optimalSwellFloor, fading out over 45° either side
+// so the factor never jumps at a sector edge. Every matching Shadow then
+// attenuates the remaining energy.
+func (s *Spot) Exposure(dir Direction) SwellExposure {
+	e := SwellExposure{EnergyFactor: max(coastlineFactor(s.Facing.Diff(dir)), s.optimalFloor(dir))}
+	if e.EnergyFactor < 1 {
+		e.BlockedBy = append(e.BlockedBy, "coastline")
+	}
+
+	for _, sh := range s.Shadows {
+		if e.EnergyFactor == 0 || !sh.Sector.Contains(dir) {
+			continue
+		}
+		e.EnergyFactor *= 1 - sh.Attenuation
+		e.BlockedBy = append(e.BlockedBy, sh.Name)
+	}
+	e.HeightFactor = math.Sqrt(e.EnergyFactor)
+	return e
+}
+
+// optimalFloor is the least energy factor the spot's optimal swell sectors
+// allow for dir: optimalSwellFloor inside a sector, fading to 0 at
+// optimalSwellTaper degrees outside the nearest one.
+func (s *Spot) optimalFloor(dir Direction) float64 {
+	if s.Optimal == nil {
+		return 0
+	}
+	floor := 0.0
+	for _, a := range s.Optimal.SwellDirections {
+		floor = max(floor, optimalSwellFloor*taper(a.Distance(dir), 0, optimalSwellTaper))
+	}
+	return floor
+}
+
+// coastlineFactor is the fraction of swell energy that wraps around the
+// coastline to a beach angle degrees off the swell direction, falling along a
+// half cosine between fullSwellAngle and maxSwellAngle.
+func coastlineFactor(angle float64) float64 {
+	return taper(angle, fullSwellAngle, maxSwellAngle)
+}
+
+// taper falls along a half cosine from 1 at or below full to 0 at or above
+// none.
+func taper(x, full, none float64) float64 {
+	switch {
+	case x <= full:
+		return 1
+	case x >= none:
+		return 0
+	}
+	return (1 + math.Cos(math.Pi*(x-full)/(none-full))) / 2
+}
+
+func (sh Shadow) validate(i int) []*FieldError {
+	var fields []*FieldError
+	field := fmt.Sprintf("shadows[%d]", i)
+	if sh.Name == "" {
+		fields = append(fields, &FieldError{Field: field + ".name", Err: ErrRequired})
+	}
+	if !sh.Sector.From.Valid() || !sh.Sector.To.Valid() {
+		fields = append(fields, &FieldError{Field: field + ".sector", Value: sh.Sector, Err: ErrOutOfRange})
+	}
+	if sh.Attenuation < 0 || sh.Attenuation > 1 {
+		fields = append(fields, &FieldError{Field: field + ".attenuation", Value: sh.Attenuation, Err: ErrOutOfRange})
+	}
+	return fields
+}
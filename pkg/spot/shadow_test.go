@@ -0,0 +1,112 @@
+package spot
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSpotExposure(t *testing.T) {
+	s := Spot{
+		Name:   "Rincon Point",
+		Facing: 225,
+		Shadows: []Shadow{
+			{Name: "Channel Islands", Sector: DirectionArc{From: 185, To: 245}, Attenuation: 0.75},
+			{Name: "Santa Cruz Island", Sector: DirectionArc{From: 200, To: 220}, Attenuation: 0.5},
+		},
+	}
+
+	testCases := []struct {
+		dir          Direction
+		energyFactor float64
+		blockedBy    int
+	}{
+		{dir: 265, energyFactor: 1, blockedBy: 0},
+		{dir: 240, energyFactor: 0.25, blockedBy: 1},
+		{dir: 210, energyFactor: 0.125, blockedBy: 2},
+		{dir: 315, energyFactor: 0.5, blockedBy: 1},
+		{dir: 45, energyFactor: 0, blockedBy: 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("Swell from %g", tt.dir.Degrees()), func(t *testing.T) {
+			e := s.Exposure(tt.dir)
+			if math.Abs(e.EnergyFactor-tt.energyFactor) > 1e-9 {
+				t.Fatalf("Expected energy factor %g, got %g", tt.energyFactor, e.EnergyFactor)
+			}
+			if math.Abs(e.HeightFactor-math.Sqrt(tt.energyFactor)) > 1e-9 {
+				t.Fatalf("Expected height factor %g, got %g", math.Sqrt(tt.energyFactor), e.HeightFactor)
+			}
+			if len(e.BlockedBy) != tt.blockedBy {
+				t.Fatalf("Expected %d blockers, got %v", tt.blockedBy, e.BlockedBy)
+			}
+		})
+	}
+}
+
+func TestSpotExposureOptimalArc(t *testing.T) {
+	// Stoney Point faces SSE but its best swell is NE-E wind swell wrapping
+	// into the point, further off its facing than the coastline taper allows.
+	var s Spot
+	for _, sp := range spots {
+		if sp.Name == "Stoney Point" {
+			s = sp
+		}
+	}
+
+	testCases := []struct {
+		dir          Direction
+		energyFactor float64
+		blockedBy    int
+	}{
+		{dir: 0, energyFactor: 0, blockedBy: 1},
+		{dir: 22.5, energyFactor: optimalSwellFloor * taper(22.5, 0, optimalSwellTaper), blockedBy: 1},
+		{dir: 45, energyFactor: optimalSwellFloor, blockedBy: 1},
+		{dir: 90, energyFactor: coastlineFactor(67.5), blockedBy: 1},
+		{dir: 157.5, energyFactor: 1, blockedBy: 0},
+		{dir: 270, energyFactor: coastlineFactor(112.5), blockedBy: 1},
+		{dir: 337.5, energyFactor: 0, blockedBy: 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("Swell from %g", tt.dir.Degrees()), func(t *testing.T) {
+			e := s.Exposure(tt.dir)
+			if math.Abs(e.EnergyFactor-tt.energyFactor) > 1e-9 {
+				t.Fatalf("Returned energy factor did not match expected energy factor:\n\tReturned: %g\n\tExpected: %g", e.EnergyFactor, tt.energyFactor)
+			}
+			if len(e.BlockedBy) != tt.blockedBy {
+				t.Fatalf("Expected %d blockers, got %v", tt.blockedBy, e.BlockedBy)
+			}
+		})
+	}
+
+	// The floor fades in and out, so the factor never jumps at a sector edge.
+	prev := s.Exposure(0).EnergyFactor
+	for deg := 0.5; deg < 360; deg += 0.5 {
+		f := s.Exposure(Direction(deg)).EnergyFactor
+		if math.Abs(f-prev) > 0.05 {
+			t.Fatalf("energy factor jumped from %g to %g at %g°", prev, f, deg)
+		}
+		prev = f
+	}
+
+	// A real shadow still attenuates swell inside the optimal sector.
+	s.Shadows = []Shadow{{Name: "Outer Island", Sector: DirectionArc{From: 40, To: 50}, Attenuation: 1}}
+	if e := s.Exposure(45); e.EnergyFactor != 0 || len(e.BlockedBy) != 2 {
+		t.Fatalf("Expected the shadow to block swell in the optimal sector, got %+v", e)
+	}
+}
+
+func TestCoastlineFactorTapers(t *testing.T) {
+	prev := 1.0
+	for angle := 0.0; angle <= 180; angle += 5 {
+		f := coastlineFactor(angle)
+		if f > prev || f < 0 || f > 1 {
+			t.Fatalf("coastline factor should fall from 1 to 0, got %g at %g° after %g", f, angle, prev)
+		}
+		prev = f
+	}
+	if f := coastlineFactor(90); math.Abs(f-0.5) > 1e-9 {
+		t.Fatalf("Expected half the energy 90° off the facing, got %g", f)
+	}
+}
@@ -11,46 +11,103 @@ const MetaNwsGridPoint = "nws_grid_point"
 
 var ErrInvalidName = errors.New("could not find a spot with the provided name")
 
+// SpotType determines which evaluation criteria and tools apply to a spot.
+type SpotType string
+
+const (
+	SpotTypeOcean SpotType = "ocean"
+	SpotTypeLake  SpotType = "lake"
+)
+
+// Valid reports whether t is a known spot type.
+func (t SpotType) Valid() bool {
+	switch t {
+	case SpotTypeOcean, SpotTypeLake:
+		return true
+	}
+	return false
+}
+
+func (t *SpotType) UnmarshalText(b []byte) error {
+	*t = SpotType(strings.ToLower(strings.TrimSpace(string(b))))
+	return nil
+}
+
+// BreakType describes what the waves break over.
+type BreakType string
+
+const (
+	BreakTypeBeach BreakType = "beach break"
+	BreakTypeReef  BreakType = "reef break"
+	BreakTypePoint BreakType = "point break"
+)
+
+// Valid reports whether t is a known break type.
+func (t BreakType) Valid() bool {
+	switch t {
+	case BreakTypeBeach, BreakTypeReef, BreakTypePoint:
+		return true
+	}
+	return false
+}
+
+func (t *BreakType) UnmarshalText(b []byte) error {
+	*t = BreakType(strings.ToLower(strings.TrimSpace(string(b))))
+	return nil
+}
+
 type Spot struct {
-	Name  string `json:"name" jsonschema_description:"The human-reable name of the spot."`
-	City  string `json:"city" jsonschema_description:"The city the Spot is located in."`
-	State string `json:"state" jsonschema_description:"The state the Spot is located in."`
+	Name    string   `json:"name" jsonschema_description:"The human-reable name of the spot."`
+	Aliases []string `json:"aliases,omitempty" jsonschema_description:"Other names the spot goes by, e.g. nicknames or abbreviations."`
+	Region  string   `json:"region,omitempty" jsonschema_description:"Broader surf region the spot belongs to, e.g. 'Southern California' or 'Lake Superior'."`
+	City    string   `json:"city" jsonschema_description:"The city the Spot is located in."`
+	State   string   `json:"state" jsonschema_description:"The state the Spot is located in."`
+
+	Timezone string `json:"timezone,omitempty" jsonschema_description:"IANA timezone of the spot, e.g. 'America/Los_Angeles'. Every time reported for the spot is converted to this zone. Defaults to the state's zone when empty."`
 
 	Longitude float32 `json:"longitude" jsonschema_description:"The longitudinal point to find the spot."`
 	Latitude  float32 `json:"latitude" jsonschema_description:"The latitudinal point to find the spot."`
 
-	SpotType  string `json:"spot_type" jsonschema_description:"The type of surf spot: 'ocean' or 'lake'. Lake spots depend entirely on locally generated wind swell; ocean spots prefer distant groundswell. Evaluation criteria differ significantly between the two."`
-	BreakType string `json:"break_type" jsonschema_description:"The type of wave break: beach break, reef break, or point break."`
-	Facing    string `json:"facing" jsonschema_description:"Cardinal direction the beach faces (e.g. WSW). Used to determine whether wind is offshore or onshore."`
+	SpotType  SpotType  `json:"spot_type" jsonschema_description:"The type of surf spot: 'ocean' or 'lake'. Lake spots depend entirely on locally generated wind swell; ocean spots prefer distant groundswell. Evaluation criteria differ significantly between the two."`
+	BreakType BreakType `json:"break_type" jsonschema_description:"The type of wave break: 'beach break', 'reef break', or 'point break'."`
+	Facing    Direction `json:"facing" jsonschema_description:"Direction the beach faces in degrees true (e.g. 247.5 for WSW). Used to determine whether wind is offshore or onshore."`
 
 	// https://www.ndbc.noaa.gov
-	NearestBuoyID string `json:"nearest_buoy_id" jsonschema_description:"NOAA NDBC station ID of the nearest offshore buoy for real-time wave observations."`
+	NearestBuoyID string        `json:"nearest_buoy_id" jsonschema_description:"NOAA NDBC station ID of the nearest offshore buoy for real-time wave observations. Used when 'buoys' is empty."`
+	Buoys         []BuoyStation `json:"buoys,omitempty" jsonschema_description:"Ordered NDBC stations feeding the spot's observations, each with a role and blending weight. Takes precedence over nearest_buoy_id."`
 
 	// https://tidesandcurrents.noaa.gov/map
 	TideStationID string `json:"tide_station_id" jsonschema_description:"NOAA CO-OPS tide gauge station ID for fetching tide predictions. Empty for lake spots where tides are negligible."`
 
-	TidalRange string         `json:"tidal_range" jsonschema_description:"The ideal tidal range for the spot(ex:6ft-4ft)."`
-	Spec       string         `json:"spec" jsonschema_description:"Additional specification information to look for at this spot."`
-	Meta       map[string]any `json:"meta" jsonschema_description:"Optional metadata to tie to the spot."`
+	Shadows []Shadow       `json:"shadows,omitempty" jsonschema_description:"Swell direction sectors blocked or weakened by islands or headlands before reaching the spot."`
+	Optimal *Conditions    `json:"optimal,omitempty" jsonschema_description:"Structured window of swell, wind and tide conditions in which the spot works best."`
+	Spec    string         `json:"spec" jsonschema_description:"Additional specification information to look for at this spot."`
+	Meta    map[string]any `json:"meta" jsonschema_description:"Optional metadata to tie to the spot."`
 }
 
 type SpotArgs struct {
-	Name string `json:"name" jsonschema_description:"The name of the spot to gather information for. Sending a name of 'all' will return all spots of interest."`
+	Name string `json:"name" jsonschema_description:"The name or alias of the spot to gather information for. Matching ignores case and tolerates typos and partial names. Sending a name of 'all' will return all spots of interest."`
+
+	State     string    `json:"state,omitempty" jsonschema_description:"Only return spots in this state, by full name or postal abbreviation (e.g. 'Minnesota' or 'MN')."`
+	SpotType  SpotType  `json:"spot_type,omitempty" jsonschema_description:"Only return spots of this type: 'ocean' or 'lake'."`
+	BreakType BreakType `json:"break_type,omitempty" jsonschema_description:"Only return spots with this break type: 'beach break', 'reef break', or 'point break'."`
+	Region    string    `json:"region,omitempty" jsonschema_description:"Only return spots whose region contains this text (e.g. 'Lake Superior')."`
 }
 
 type SpotsResult struct {
 	Spots []Spot `json:"spots"`
 }
 
+// GetSpotsOfInterest serves spots from the default Registry, which holds either
+// the embedded watch list or the file named by SpotsFileEnv. Names are matched
+// with MatchName and the result is narrowed by any filters set on args.
 func GetSpotsOfInterest(_ tool.Context, args SpotArgs) (SpotsResult, error) {
-	if strings.EqualFold(args.Name, "all") {
-		return SpotsResult{Spots: spots}, nil
-	}
-
-	for _, s := range spots {
-		if strings.EqualFold(s.Name, args.Name) {
-			return SpotsResult{Spots: []Spot{s}}, nil
+	spots := defaultRegistry.Spots()
+	if !strings.EqualFold(args.Name, "all") && args.Name != "" {
+		var err error
+		if spots, err = MatchName(spots, args.Name); err != nil {
+			return SpotsResult{}, err
 		}
 	}
-	return SpotsResult{}, ErrInvalidName
+	return SpotsResult{Spots: filterSpots(spots, args)}, nil
 }
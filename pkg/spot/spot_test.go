@@ -1,6 +1,7 @@
 package spot
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -8,6 +9,7 @@ import (
 func TestGetSpotsOfInterest(t *testing.T) {
 	testCases := []struct {
 		name      string
+		args      SpotArgs
 		expected  error
 		returnLen int
 	}{
@@ -21,12 +23,60 @@ func TestGetSpotsOfInterest(t *testing.T) {
 			expected:  ErrInvalidName,
 			returnLen: 0,
 		},
+		{
+			name:      "Rincon",
+			returnLen: 1,
+		},
+		{
+			name:      "stoney pt",
+			returnLen: 1,
+		},
+		{
+			name:      "Ocen Beach",
+			returnLen: 1,
+		},
+		{
+			name:      "emp",
+			returnLen: 1,
+		},
+		{
+			name:      "stoney",
+			returnLen: 1,
+		},
+		{
+			name:      "rinc pt",
+			returnLen: 1,
+		},
+		{
+			name:      "pire bea",
+			returnLen: 1,
+		},
+		{
+			name:      "OB",
+			returnLen: 1,
+		},
+		{
+			name:      "all",
+			args:      SpotArgs{State: "MN", SpotType: SpotTypeLake},
+			returnLen: 1,
+		},
+		{
+			name:      "all",
+			args:      SpotArgs{Region: "southern california", BreakType: BreakTypePoint},
+			returnLen: 1,
+		},
+		{
+			name:      "all",
+			args:      SpotArgs{State: "Minnesota", SpotType: SpotTypeOcean},
+			returnLen: 0,
+		},
 	}
 
 	for _, tt := range testCases {
-		t.Run(fmt.Sprintf("Spot %s", tt.name), func(t *testing.T) {
-			result, err := GetSpotsOfInterest(nil, SpotArgs{Name: tt.name})
-			if err != tt.expected {
+		t.Run(fmt.Sprintf("Spot %s %+v", tt.name, tt.args), func(t *testing.T) {
+			tt.args.Name = tt.name
+			result, err := GetSpotsOfInterest(nil, tt.args)
+			if !errors.Is(err, tt.expected) {
 				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, tt.expected)
 			}
 
@@ -36,3 +86,14 @@ func TestGetSpotsOfInterest(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchNameSuggestions(t *testing.T) {
+	_, err := MatchName(spots, "Rincone Pointe Beach")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Fatalf("expected a *NotFoundError, got %v", err)
+	}
+	if len(nf.Suggestions) == 0 || nf.Suggestions[0] != "Rincon Point" {
+		t.Fatalf("Expected Rincon Point as the first suggestion, got %v", nf.Suggestions)
+	}
+}
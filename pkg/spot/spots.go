@@ -1,64 +1,128 @@
 package spot
 
+// spots is the embedded default watch list, served when no spots file is
+// configured.
 var spots = []Spot{
 	{
 		Name:          "Ocean Beach",
+		Aliases:       []string{"OB", "OB San Diego"},
+		Region:        "Southern California",
 		City:          "San Diego",
 		State:         "California",
+		Timezone:      "America/Los_Angeles",
 		Latitude:      32.7487318,
 		Longitude:     -117.2583427,
-		SpotType:      "ocean",
-		BreakType:     "beach break",
-		Facing:        "WSW",
+		SpotType:      SpotTypeOcean,
+		BreakType:     BreakTypeBeach,
+		Facing:        247.5, // WSW
 		NearestBuoyID: "46086",
+		Buoys: []BuoyStation{
+			{ID: "46232", Role: RoleWaves, Weight: 0.6},
+			{ID: "46086", Role: RoleWaves, Weight: 0.4},
+			{ID: "LJPC1", Role: RoleWind, Weight: 1},
+		},
 		TideStationID: "9410170",
-		TidalRange:    ">2ft",
-		Spec:          "Beach break with shifting sandbars. Mornings traditionally better than afternoons. Highly exposed spot — conditions are frequently rougher than forecasts suggest. Strong rip currents are common, especially with wind > 15 mph or during large swell. Exercise caution in strong wind regardless of direction. Best swell directions: NW to W; SW also works. Holds up to ~8ft — above that most sets close out across the entire beach. Very low or negative tides accelerate closeout tendency. Best season: November through February (NW groundswell season).",
-		Meta:          map[string]any{},
+		Optimal: &Conditions{
+			// NW to W is best; SW also works.
+			SwellDirections: []DirectionArc{{From: 225, To: 315}},
+			MaxHoldableFt:   feet(8),
+			OffshoreWind:    &DirectionArc{From: 22.5, To: 112.5},
+			TideFt:          AtLeast(2),
+		},
+		Shadows: []Shadow{
+			{Name: "Point Loma", Sector: DirectionArc{From: 150, To: 195}, Attenuation: 0.6},
+			{Name: "San Clemente and Santa Catalina Islands", Sector: DirectionArc{From: 270, To: 295}, Attenuation: 0.3},
+		},
+		Spec: "Beach break with shifting sandbars. Mornings traditionally better than afternoons. Highly exposed spot — conditions are frequently rougher than forecasts suggest. Strong rip currents are common, especially with wind > 15 mph or during large swell. Exercise caution in strong wind regardless of direction. Above ~8ft most sets close out across the entire beach. Very low or negative tides accelerate closeout tendency. Best season: November through February (NW groundswell season).",
+		Meta: map[string]any{},
 	},
 	{
 		Name:          "Rincon Point",
+		Aliases:       []string{"Rincon", "Queen of the Coast"},
+		Region:        "Southern California",
 		City:          "Carpinteria",
 		State:         "California",
+		Timezone:      "America/Los_Angeles",
 		Latitude:      34.3728477,
 		Longitude:     -119.4984414,
-		SpotType:      "ocean",
-		BreakType:     "point break",
-		Facing:        "SW",
+		SpotType:      SpotTypeOcean,
+		BreakType:     BreakTypePoint,
+		Facing:        225, // SW
 		NearestBuoyID: "46053",
+		Buoys: []BuoyStation{
+			{ID: "46053", Role: RoleWaves, Weight: 1},
+			{ID: "46054", Role: RoleUpstreamSwell, Weight: 1},
+		},
 		TideStationID: "9411340",
-		TidalRange:    ">2ft",
-		Spec:          "Classic California point break, known as the 'Queen of the Coast.' Optimal swell: WNW to W (250–280°), 4–8ft, 13s+ period. Key nuance: Rincon breaks significantly smaller than nearby spots when NW swell period is very long (>16s) — the swell wraps around the point and loses energy; conditions improve when period drops below 16s or swell shifts more WSW. All three sections (The Point, Rivermouth, Indicator) improve as the tide drops. Best at low to mid falling tide. Offshore wind: NE. Best season: October through March (west/northwest groundswell season).",
-		Meta:          map[string]any{},
+		Optimal: &Conditions{
+			SwellDirections: []DirectionArc{{From: 250, To: 280}},
+			SwellPeriodS:    Between(13, 16),
+			SwellHeightFt:   Between(4, 8),
+			OffshoreWind:    &DirectionArc{From: 22.5, To: 67.5},
+			TideFt:          AtLeast(2),
+			TidePhase:       TidePhaseFalling,
+		},
+		Shadows: []Shadow{
+			{Name: "Channel Islands", Sector: DirectionArc{From: 185, To: 245}, Attenuation: 0.7},
+			{Name: "Point Conception", Sector: DirectionArc{From: 285, To: 330}, Attenuation: 0.8},
+		},
+		Spec: "Classic California point break, known as the 'Queen of the Coast.' Key nuance: Rincon breaks significantly smaller than nearby spots when NW swell period is very long (>16s) — the swell wraps around the point and loses energy; conditions improve when period drops below 16s or swell shifts more WSW. All three sections (The Point, Rivermouth, Indicator) improve as the tide drops. Best at low to mid falling tide. Best season: October through March (west/northwest groundswell season).",
+		Meta: map[string]any{},
 	},
 	{
 		Name:          "Empire Beach",
+		Aliases:       []string{"Empire"},
+		Region:        "Lake Michigan",
 		City:          "Empire",
 		State:         "Michigan",
+		Timezone:      "America/Detroit",
 		Latitude:      44.8120363,
 		Longitude:     -86.1093288,
-		SpotType:      "lake",
-		BreakType:     "beach break",
-		Facing:        "W",
+		SpotType:      SpotTypeLake,
+		BreakType:     BreakTypeBeach,
+		Facing:        270, // W
 		NearestBuoyID: "BSBM4",
-		TideStationID: "N/A",
-		TidalRange:    "N/A",
-		Spec:          "W/NW winds produce ~60 miles of fetch — small to moderate waves. S/SW winds produce 250+ miles of fetch across the full length of Lake Michigan — best swell quality with longer periods and larger wave heights. Best conditions come from sustained S/SW winds at 15+ mph for 2+ days. Summer surfing is generally inconsistent; fall through early spring is the prime season.",
-		Meta:          map[string]any{},
+		Buoys: []BuoyStation{
+			{ID: "45002", Role: RoleWaves, Weight: 1},
+			{ID: "BSBM4", Role: RoleWind, Weight: 1},
+		},
+		Optimal: &Conditions{
+			// S/SW runs the full length of Lake Michigan; W/NW has less fetch
+			// but still produces small to moderate waves.
+			SwellDirections: []DirectionArc{{From: 180, To: 315}},
+			OffshoreWind:    &DirectionArc{From: 45, To: 135},
+		},
+		Spec: "W/NW winds cross only the width of Lake Michigan — small to moderate waves. SW winds blow up the length of the lake — best swell quality with longer periods and larger wave heights. Use get_fetch for the fetch in miles for a given wind direction. Best conditions come from sustained S/SW winds at 15+ mph for 2+ days. Summer surfing is generally inconsistent; fall through early spring is the prime season.",
+		Meta: map[string]any{},
 	},
 	{
 		Name:          "Stoney Point",
+		Aliases:       []string{"Stony Point"},
+		Region:        "Lake Superior",
 		City:          "Duluth",
 		State:         "Minnesota",
+		Timezone:      "America/Chicago",
 		Latitude:      46.9666696,
 		Longitude:     -91.6359906,
-		SpotType:      "lake",
-		BreakType:     "point break",
-		Facing:        "SSE",
+		SpotType:      SpotTypeLake,
+		BreakType:     BreakTypePoint,
+		Facing:        157.5, // SSE
 		NearestBuoyID: "SLVM5",
-		TideStationID: "N/A",
-		TidalRange:    "N/A",
-		Spec:          "Rocky point break on the MN North Shore of Lake Superior. Lake surf depends entirely on wind-generated swell — there is no groundswell. Requires 2-3 days of sustained NE or NW winds at 15+ mph to build surfable waves. Classic pattern: NE/N winds (onshore) build waves across the lake, then a shift to NW (offshore) cleans up the faces. Gale warnings (34-47 knots) issued for western Lake Superior are a strong positive signal — prime surf conditions. Storm warnings (48+ knots) can produce 6-8ft+ waves but may be dangerous even for experienced surfers. 4-6ft waves are ideal. No tidal influence. Best season: late fall and winter when low-pressure systems produce frequent gales.",
-		Meta:          map[string]any{},
+		Buoys: []BuoyStation{
+			{ID: "45028", Role: RoleWaves, Weight: 1},
+			{ID: "SLVM5", Role: RoleWind, Weight: 1},
+		},
+		Optimal: &Conditions{
+			SwellDirections: []DirectionArc{{From: 45, To: 90}},
+			SwellHeightFt:   Between(4, 6),
+			MaxHoldableFt:   feet(8),
+			OffshoreWind:    &DirectionArc{From: 292.5, To: 337.5},
+		},
+		Spec: "Rocky point break on the MN North Shore of Lake Superior. Lake surf depends entirely on wind-generated swell — there is no groundswell. Requires 2-3 days of sustained NE or NW winds at 15+ mph to build surfable waves. Classic pattern: NE/N winds (onshore) build waves across the lake, then a shift to NW (offshore) cleans up the faces. Gale warnings (34-47 knots) issued for western Lake Superior are a strong positive signal — prime surf conditions. Storm warnings (48+ knots) can produce 6-8ft+ waves but may be dangerous even for experienced surfers. No tidal influence. Best season: late fall and winter when low-pressure systems produce frequent gales.",
+		Meta: map[string]any{},
 	},
 }
+
+func feet(v float64) *float64 {
+	return &v
+}
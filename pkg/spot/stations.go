@@ -0,0 +1,63 @@
+package spot
+
+import (
+	"fmt"
+)
+
+// StationRole is what a spot uses a buoy or shore station for.
+type StationRole string
+
+const (
+	// RoleWaves stations describe the swell arriving at the spot and are
+	// blended into its wave summary.
+	RoleWaves StationRole = "waves"
+	// RoleWind stations describe local wind, e.g. C-MAN shore stations.
+	RoleWind StationRole = "wind"
+	// RoleUpstreamSwell stations sit further out along the swell path and give
+	// early warning of swell before it arrives. They are reported but not
+	// blended.
+	RoleUpstreamSwell StationRole = "upstream_swell"
+)
+
+// Valid reports whether r is a known station role.
+func (r StationRole) Valid() bool {
+	switch r {
+	case RoleWaves, RoleWind, RoleUpstreamSwell:
+		return true
+	}
+	return false
+}
+
+// BuoyStation is one NDBC station feeding a spot's observations.
+type BuoyStation struct {
+	ID     string      `json:"id" jsonschema_description:"NOAA NDBC station ID."`
+	Role   StationRole `json:"role" jsonschema_description:"What the station is used for: 'waves', 'wind', or 'upstream_swell'."`
+	Weight float64     `json:"weight" jsonschema_description:"Relative weight when blending stations that share a role. Weights are normalized, so 2 and 1 means two-thirds and one-third."`
+}
+
+// Stations returns the spot's ordered station list. Spots without an explicit
+// list fall back to NearestBuoyID as a single waves station.
+func (s *Spot) Stations() []BuoyStation {
+	if len(s.Buoys) > 0 {
+		return s.Buoys
+	}
+	if s.NearestBuoyID == "" {
+		return nil
+	}
+	return []BuoyStation{{ID: s.NearestBuoyID, Role: RoleWaves, Weight: 1}}
+}
+
+func (b BuoyStation) validate(i int) []*FieldError {
+	var fields []*FieldError
+	field := fmt.Sprintf("buoys[%d]", i)
+	if !buoyIDPattern.MatchString(b.ID) {
+		fields = append(fields, &FieldError{Field: field + ".id", Value: b.ID, Err: ErrInvalidFormat})
+	}
+	if !b.Role.Valid() {
+		fields = append(fields, &FieldError{Field: field + ".role", Value: b.Role, Err: ErrInvalidValue})
+	}
+	if b.Weight <= 0 {
+		fields = append(fields, &FieldError{Field: field + ".weight", Value: b.Weight, Err: ErrOutOfRange})
+	}
+	return fields
+}
@@ -0,0 +1,69 @@
+package spot
+
+import (
+	"strings"
+	"time"
+
+	// Embed the IANA database so spot timezones resolve on hosts and
+	// containers without tzdata installed.
+	_ "time/tzdata"
+)
+
+// stateTimezones maps US postal abbreviations to the IANA zone covering most
+// of the state's coastline. States split across zones use the zone of their
+// surfable coast, e.g. Michigan's Lake Michigan shore and Florida's Atlantic
+// coast.
+var stateTimezones = map[string]string{
+	"AL": "America/Chicago", "AK": "America/Anchorage", "AZ": "America/Phoenix",
+	"AR": "America/Chicago", "CA": "America/Los_Angeles", "CO": "America/Denver",
+	"CT": "America/New_York", "DE": "America/New_York", "FL": "America/New_York",
+	"GA": "America/New_York", "HI": "Pacific/Honolulu", "ID": "America/Boise",
+	"IL": "America/Chicago", "IN": "America/Indiana/Indianapolis", "IA": "America/Chicago",
+	"KS": "America/Chicago", "KY": "America/New_York", "LA": "America/Chicago",
+	"ME": "America/New_York", "MD": "America/New_York", "MA": "America/New_York",
+	"MI": "America/Detroit", "MN": "America/Chicago", "MS": "America/Chicago",
+	"MO": "America/Chicago", "MT": "America/Denver", "NE": "America/Chicago",
+	"NV": "America/Los_Angeles", "NH": "America/New_York", "NJ": "America/New_York",
+	"NM": "America/Denver", "NY": "America/New_York", "NC": "America/New_York",
+	"ND": "America/Chicago", "OH": "America/New_York", "OK": "America/Chicago",
+	"OR": "America/Los_Angeles", "PA": "America/New_York", "RI": "America/New_York",
+	"SC": "America/New_York", "SD": "America/Chicago", "TN": "America/Chicago",
+	"TX": "America/Chicago", "UT": "America/Denver", "VT": "America/New_York",
+	"VA": "America/New_York", "WA": "America/Los_Angeles", "WV": "America/New_York",
+	"WI": "America/Chicago", "WY": "America/Denver", "PR": "America/Puerto_Rico",
+}
+
+// Location returns the spot's timezone. Spots without a timezone fall back to
+// the predominant zone of their state, and to UTC when the state is unknown.
+func (s *Spot) Location() *time.Location {
+	if s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	if name, ok := stateTimezones[stateCode(s.State)]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// Now returns the current time in the spot's timezone.
+func (s *Spot) Now() time.Time {
+	return time.Now().In(s.Location())
+}
+
+// stateCode returns the postal abbreviation for a state name or abbreviation.
+func stateCode(state string) string {
+	state = strings.TrimSpace(state)
+	if _, ok := stateNames[strings.ToUpper(state)]; ok {
+		return strings.ToUpper(state)
+	}
+	for code, name := range stateNames {
+		if strings.EqualFold(name, state) {
+			return code
+		}
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package spot
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSpotLocation(t *testing.T) {
+	testCases := []struct {
+		spot     Spot
+		expected string
+	}{
+		{spot: Spot{Timezone: "America/Detroit", State: "California"}, expected: "America/Detroit"},
+		{spot: Spot{State: "California"}, expected: "America/Los_Angeles"},
+		{spot: Spot{State: "mn"}, expected: "America/Chicago"},
+		{spot: Spot{Timezone: "Pacific/Atlantis", State: "Hawaii"}, expected: "Pacific/Honolulu"},
+		{spot: Spot{State: "Baja California Sur"}, expected: "UTC"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("%q in %q", tt.spot.Timezone, tt.spot.State), func(t *testing.T) {
+			if got := tt.spot.Location().String(); got != tt.expected {
+				t.Fatalf("Returned location did not match expected location:\n\tReturned: %s\n\tExpected: %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package spot
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRequired      = errors.New("is required")
+	ErrOutOfRange    = errors.New("is out of range")
+	ErrInvalidFormat = errors.New("has an invalid format")
+	ErrInvalidValue  = errors.New("is not a recognized value")
+)
+
+var (
+	// NDBC station IDs are five alphanumeric characters, e.g. 46086 or BSBM4.
+	buoyIDPattern = regexp.MustCompile(`^[A-Z0-9]{5}$`)
+	// CO-OPS station IDs are seven digits, e.g. 9410170.
+	tideStationIDPattern = regexp.MustCompile(`^[0-9]{7}$`)
+)
+
+// FieldError describes a single invalid field on a Spot.
+type FieldError struct {
+	Field string
+	Value any
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("%s %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s %v (got %v)", e.Field, e.Err, e.Value)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError aggregates every FieldError found on a single Spot.
+type ValidationError struct {
+	Spot   string
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+
+	name := e.Spot
+	if name == "" {
+		name = "unnamed spot"
+	}
+	return fmt.Sprintf("invalid spot %q: %s", name, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Validate checks the spot's required fields, coordinate bounds, enum values
+// and station ID formats. It returns a *ValidationError listing every problem,
+// or nil when the spot is valid.
+func (s *Spot) Validate() error {
+	var fields []*FieldError
+	add := func(field string, value any, err error) {
+		fields = append(fields, &FieldError{Field: field, Value: value, Err: err})
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		add("name", nil, ErrRequired)
+	}
+
+	if s.Latitude < -90 || s.Latitude > 90 {
+		add("latitude", s.Latitude, ErrOutOfRange)
+	}
+	if s.Longitude < -180 || s.Longitude > 180 {
+		add("longitude", s.Longitude, ErrOutOfRange)
+	}
+	if s.Latitude == 0 && s.Longitude == 0 {
+		add("latitude/longitude", nil, ErrRequired)
+	}
+
+	switch {
+	case s.SpotType == "":
+		add("spot_type", nil, ErrRequired)
+	case !s.SpotType.Valid():
+		add("spot_type", s.SpotType, ErrInvalidValue)
+	}
+	switch {
+	case s.BreakType == "":
+		add("break_type", nil, ErrRequired)
+	case !s.BreakType.Valid():
+		add("break_type", s.BreakType, ErrInvalidValue)
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			add("timezone", s.Timezone, ErrInvalidValue)
+		}
+	}
+	if !s.Facing.Valid() {
+		add("facing", s.Facing.Degrees(), ErrOutOfRange)
+	}
+
+	if s.NearestBuoyID != "" && !buoyIDPattern.MatchString(s.NearestBuoyID) {
+		add("nearest_buoy_id", s.NearestBuoyID, ErrInvalidFormat)
+	}
+	if s.TideStationID != "" && !tideStationIDPattern.MatchString(s.TideStationID) {
+		add("tide_station_id", s.TideStationID, ErrInvalidFormat)
+	}
+
+	fields = append(fields, s.Optimal.validate()...)
+	for i, b := range s.Buoys {
+		fields = append(fields, b.validate(i)...)
+	}
+	for i, sh := range s.Shadows {
+		fields = append(fields, sh.validate(i)...)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Spot: s.Name, Fields: fields}
+}
@@ -0,0 +1,109 @@
+package spot
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedSpotsValidate(t *testing.T) {
+	for _, s := range spots {
+		t.Run(s.Name, func(t *testing.T) {
+			if err := s.Validate(); err != nil {
+				t.Fatalf("embedded spot failed validation: %v", err)
+			}
+		})
+	}
+}
+
+func TestSpotValidate(t *testing.T) {
+	s := Spot{
+		Latitude:      95,
+		Longitude:     -117,
+		SpotType:      "river",
+		Facing:        400,
+		NearestBuoyID: "4608",
+		TideStationID: "N/A",
+		Timezone:      "Pacific/Atlantis",
+	}
+
+	err := s.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+
+	expected := map[string]error{
+		"name":            ErrRequired,
+		"latitude":        ErrOutOfRange,
+		"spot_type":       ErrInvalidValue,
+		"break_type":      ErrRequired,
+		"facing":          ErrOutOfRange,
+		"nearest_buoy_id": ErrInvalidFormat,
+		"tide_station_id": ErrInvalidFormat,
+		"timezone":        ErrInvalidValue,
+	}
+	if len(verr.Fields) != len(expected) {
+		t.Fatalf("Expected %d field errors, got %d: %v", len(expected), len(verr.Fields), err)
+	}
+	for _, f := range verr.Fields {
+		if !errors.Is(f, expected[f.Field]) {
+			t.Errorf("field %s:\n\tReturned: %v\n\tExpected: %v", f.Field, f.Err, expected[f.Field])
+		}
+	}
+}
+
+func TestSpotFileRequiresFacing(t *testing.T) {
+	noFacing := `spots:
+  - name: Stinson Beach
+    latitude: 37.9
+    longitude: -122.64
+    spot_type: ocean
+    break_type: beach break
+  - name: Bolinas
+    latitude: 37.9
+    longitude: -122.68
+    spot_type: ocean
+    break_type: beach break
+    facing: 0
+`
+	_, err := ReadFile(writeSpotsFile(t, "spots.yaml", noFacing))
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected a missing facing ValidationError, got %v", err)
+	}
+	if verr.Spot != "Stinson Beach" || verr.Fields[0].Field != "facing" {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: Stinson Beach facing %v", err, ErrRequired)
+	}
+	if strings.Contains(err.Error(), "Bolinas") {
+		t.Fatalf("an explicit facing of 0 (north) should be accepted, got %v", err)
+	}
+}
+
+func TestDirectionUnmarshal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Direction
+		err      error
+	}{
+		{input: `"WSW"`, expected: 247.5},
+		{input: `"sse"`, expected: 157.5},
+		{input: `"270"`, expected: 270},
+		{input: `90`, expected: 90},
+		{input: `"West-ish"`, err: ErrInvalidDirection},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.input, func(t *testing.T) {
+			var d Direction
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, tt.err)
+			}
+			if d != tt.expected {
+				t.Fatalf("Expected %v, got %v", tt.expected, d)
+			}
+		})
+	}
+}
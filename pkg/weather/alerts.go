@@ -16,8 +16,8 @@ type NwsAlert struct {
 	Headline    string `json:"headline" jsonschema_description:"Short one-line summary of the alert."`
 	Description string `json:"description" jsonschema_description:"Full alert text including wind speeds, wave heights, and timing."`
 	Severity    string `json:"severity" jsonschema_description:"NWS severity level: Extreme, Severe, Moderate, Minor, or Unknown."`
-	Effective   string `json:"effective" jsonschema_description:"ISO8601 timestamp when the alert becomes effective."`
-	Expires     string `json:"expires" jsonschema_description:"ISO8601 timestamp when the alert expires."`
+	Effective   string `json:"effective" jsonschema_description:"RFC3339 time when the alert becomes effective, in the spot's timezone."`
+	Expires     string `json:"expires" jsonschema_description:"RFC3339 time when the alert expires, in the spot's timezone."`
 }
 
 // NwsAlertsResp holds all active NWS alerts for a location.
@@ -85,8 +85,8 @@ func GetNwsAlerts(ctx tool.Context, s *spot.Spot) (*NwsAlertsResp, error) {
 			Headline:    p.Headline,
 			Description: p.Description,
 			Severity:    p.Severity,
-			Effective:   p.Effective,
-			Expires:     p.Expires,
+			Effective:   localizeTimestamp(s, p.Effective),
+			Expires:     localizeTimestamp(s, p.Expires),
 		})
 	}
 
@@ -2,23 +2,34 @@ package weather
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/louislef299/wave-report-agent/pkg/spot"
 	"google.golang.org/adk/tool"
 )
 
+// ndbcTimeFormat is the layout of the YY MM DD hh mm columns in NDBC realtime
+// files, which are in UTC.
+const ndbcTimeFormat = "2006 01 02 15 04"
+
+// ndbcRealtimeUrl is the NDBC realtime data directory, swapped out in tests.
+var ndbcRealtimeUrl = "https://www.ndbc.noaa.gov/data/realtime2"
+
 // BuoyObservation holds the most recent real-time observation from a NOAA NDBC
 // buoy. Wave height is in feet, wind speed in mph, directions in degrees true.
 // A value of -1 indicates the measurement was unavailable (reported as "MM" by
 // NDBC).
 type BuoyObservation struct {
-	StationID        string  `json:"station_id"`
+	StationID        string  `json:"station_id" jsonschema_description:"NDBC station ID. Comma-separated contributing stations for a blended observation."`
 	WindDirectionDeg float64 `json:"wind_direction_deg" jsonschema_description:"Wind direction in degrees true (where wind is coming FROM). -1 if unavailable."`
 	WindSpeedMph     float64 `json:"wind_speed_mph" jsonschema_description:"Wind speed in mph. -1 if unavailable."`
 	GustSpeedMph     float64 `json:"gust_speed_mph" jsonschema_description:"Gust speed in mph. -1 if unavailable."`
@@ -26,29 +37,223 @@ type BuoyObservation struct {
 	DominantPeriodS  float64 `json:"dominant_period_s" jsonschema_description:"Dominant wave period in seconds. -1 if unavailable."`
 	MeanWaveDirDeg   float64 `json:"mean_wave_dir_deg" jsonschema_description:"Mean wave direction in degrees true (where waves are coming FROM). -1 if unavailable."`
 	WaterTempC       float64 `json:"water_temp_c" jsonschema_description:"Water temperature in Celsius. -1 if unavailable."`
-	ObservationTime  string  `json:"observation_time" jsonschema_description:"UTC time of this observation in format YYYY-MM-DD HH:mm."`
+	ObservationTime  string  `json:"observation_time" jsonschema_description:"Time of this observation in RFC3339, in the spot's timezone. For a blended observation, the newest station reading."`
+	WaterTempTime    string  `json:"water_temp_time,omitempty" jsonschema_description:"For a blended observation, time of the oldest reading averaged into water_temp_c in RFC3339, in the spot's timezone."`
+}
+
+// StationObservation is the latest reading from one of a spot's stations.
+type StationObservation struct {
+	ID          string           `json:"id" jsonschema_description:"NOAA NDBC station ID."`
+	Role        spot.StationRole `json:"role" jsonschema_description:"What the spot uses this station for: 'waves', 'wind', or 'upstream_swell'."`
+	Weight      float64          `json:"weight" jsonschema_description:"Normalized blending weight within the station's role, from 0 to 1."`
+	Observation *BuoyObservation `json:"observation" jsonschema_description:"Latest observation. Null when the station could not be read."`
+	Error       string           `json:"error,omitempty" jsonschema_description:"Why the station could not be read."`
 }
 
-// GetBuoyObservations fetches the latest real-time observation from the NOAA
-// NDBC buoy nearest to the given surf spot. Returns the most recent reading
-// that has wave data.
-func GetBuoyObservations(_ tool.Context, s *spot.Spot) (*BuoyObservation, error) {
-	if s.NearestBuoyID == "" || s.NearestBuoyID == "N/A" {
+// BuoyObservationsResp holds per-station observations for a spot and a
+// weighted blend of them.
+type BuoyObservationsResp struct {
+	Stations []StationObservation `json:"stations"`
+	Blended  *BuoyObservation     `json:"blended" jsonschema_description:"Weighted blend of the spot's stations: wave fields from 'waves' stations, wind fields from 'wind' stations (falling back to 'waves' stations), water temperature from any station. Upstream swell stations are not blended. Null when no waves or wind station could be read."`
+}
+
+// GetBuoyObservations fetches the latest real-time observation from every NOAA
+// NDBC station configured for the spot and blends them by role and weight.
+// Each station returns its most recent reading that has wave data. Stations
+// that fail are reported individually; an error is returned only when none
+// could be read.
+func GetBuoyObservations(_ tool.Context, s *spot.Spot) (*BuoyObservationsResp, error) {
+	stations := s.Stations()
+	if len(stations) == 0 {
 		return nil, nil
 	}
 
-	url := fmt.Sprintf("https://www.ndbc.noaa.gov/data/realtime2/%s.txt", s.NearestBuoyID)
+	// Fetch each distinct station once, concurrently.
+	type result struct {
+		obs *BuoyObservation
+		err error
+	}
+	results := make(map[string]*result, len(stations))
+	var wg sync.WaitGroup
+	for _, st := range stations {
+		if _, ok := results[st.ID]; ok {
+			continue
+		}
+		r := &result{}
+		results[st.ID] = r
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			r.obs, r.err = fetchBuoyObservation(id, s.Location())
+		}(st.ID)
+	}
+	wg.Wait()
+
+	roleWeights := make(map[spot.StationRole]float64)
+	for _, st := range stations {
+		roleWeights[st.Role] += st.Weight
+	}
+
+	resp := &BuoyObservationsResp{Stations: make([]StationObservation, 0, len(stations))}
+	var errs []error
+	read := false
+	for _, st := range stations {
+		r := results[st.ID]
+		so := StationObservation{
+			ID:          st.ID,
+			Role:        st.Role,
+			Weight:      math.Round(st.Weight/roleWeights[st.Role]*100) / 100,
+			Observation: r.obs,
+		}
+		if r.err != nil {
+			so.Error = r.err.Error()
+			errs = append(errs, r.err)
+		}
+		read = read || r.obs != nil
+		resp.Stations = append(resp.Stations, so)
+	}
+	if !read {
+		return nil, fmt.Errorf("no station for %s could be read: %w", s.Name, errors.Join(errs...))
+	}
+
+	resp.Blended = blendObservations(stations, resp.Stations)
+	return resp, nil
+}
+
+// fetchBuoyObservation fetches the latest observation for a single NDBC
+// station, with its time in loc.
+func fetchBuoyObservation(stationID string, loc *time.Location) (*BuoyObservation, error) {
+	url := fmt.Sprintf("%s/%s.txt", ndbcRealtimeUrl, stationID)
 	resp, err := http.Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("fetching buoy %s: %w", s.NearestBuoyID, err)
+		return nil, fmt.Errorf("fetching buoy %s: %w", stationID, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ErrInvalidHttpResponse
+		return nil, fmt.Errorf("fetching buoy %s: %w", stationID, ErrInvalidHttpResponse)
 	}
 
-	return parseBuoyData(resp.Body, s.NearestBuoyID)
+	return parseBuoyData(resp.Body, stationID, loc)
+}
+
+// weightedMean accumulates a weighted average, skipping missing (-1) values.
+type weightedMean struct {
+	sum, weight float64
+}
+
+func (m *weightedMean) add(v, w float64) {
+	if v < 0 {
+		return
+	}
+	m.sum += v * w
+	m.weight += w
+}
+
+func (m *weightedMean) value() float64 {
+	if m.weight == 0 {
+		return -1
+	}
+	return math.Round(m.sum/m.weight*10) / 10
+}
+
+// weightedDirection accumulates a weighted circular mean of directions in
+// degrees, so 350° and 10° average to 0° rather than 180°.
+type weightedDirection struct {
+	x, y, weight float64
+}
+
+func (m *weightedDirection) add(deg, w float64) {
+	if deg < 0 {
+		return
+	}
+	rad := deg * math.Pi / 180
+	m.x += math.Cos(rad) * w
+	m.y += math.Sin(rad) * w
+	m.weight += w
+}
+
+func (m *weightedDirection) value() float64 {
+	if m.weight == 0 {
+		return -1
+	}
+	deg := math.Round(math.Atan2(m.y, m.x) * 180 / math.Pi)
+	return math.Mod(deg+360, 360)
+}
+
+// blendObservations combines station observations into a single summary.
+// Returns nil when no station reported anything.
+func blendObservations(stations []spot.BuoyStation, observed []StationObservation) *BuoyObservation {
+	var (
+		waveHeight, period, waterTemp weightedMean
+		waveDir                       weightedDirection
+		wind, gust                    [2]weightedMean
+		windDir                       [2]weightedDirection
+		ids                           []string
+		latest, waterOldest           time.Time
+	)
+
+	for i, st := range stations {
+		obs := observed[i].Observation
+		if obs == nil || st.Role == spot.RoleUpstreamSwell {
+			continue
+		}
+		if !slices.Contains(ids, obs.StationID) {
+			ids = append(ids, obs.StationID)
+		}
+		t, err := time.Parse(time.RFC3339, obs.ObservationTime)
+		if err == nil && t.After(latest) {
+			latest = t
+		}
+		// Wind-only stations often lack water temperature, so the blend's
+		// water temperature is dated by the stations that supplied it.
+		if err == nil && obs.WaterTempC >= 0 && (waterOldest.IsZero() || t.Before(waterOldest)) {
+			waterOldest = t
+		}
+
+		// Index 0 collects dedicated wind stations, index 1 wave stations as a
+		// fallback when no wind station reported.
+		src := 1
+		if st.Role == spot.RoleWind {
+			src = 0
+		}
+		wind[src].add(obs.WindSpeedMph, st.Weight)
+		gust[src].add(obs.GustSpeedMph, st.Weight)
+		windDir[src].add(obs.WindDirectionDeg, st.Weight)
+		waterTemp.add(obs.WaterTempC, st.Weight)
+
+		if st.Role == spot.RoleWaves {
+			waveHeight.add(obs.WaveHeightFt, st.Weight)
+			period.add(obs.DominantPeriodS, st.Weight)
+			waveDir.add(obs.MeanWaveDirDeg, st.Weight)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	src := 0
+	if wind[0].weight == 0 {
+		src = 1
+	}
+	var waterTime string
+	if !waterOldest.IsZero() {
+		waterTime = waterOldest.Format(time.RFC3339)
+	}
+	return &BuoyObservation{
+		StationID:        strings.Join(ids, ","),
+		WindDirectionDeg: windDir[src].value(),
+		WindSpeedMph:     wind[src].value(),
+		GustSpeedMph:     gust[src].value(),
+		WaveHeightFt:     waveHeight.value(),
+		DominantPeriodS:  period.value(),
+		MeanWaveDirDeg:   waveDir.value(),
+		WaterTempC:       waterTemp.value(),
+		ObservationTime:  latest.Format(time.RFC3339),
+		WaterTempTime:    waterTime,
+	}
 }
 
 // parseBuoyData parses the NDBC standard meteorological text format.
@@ -56,7 +261,8 @@ func GetBuoyObservations(_ tool.Context, s *spot.Spot) (*BuoyObservation, error)
 // newest-first.
 // Columns: YY MM DD hh mm WDIR WSPD GST WVHT DPD APD MWD PRES ATMP WTMP DEWP
 // VIS PTDY TIDE
-func parseBuoyData(r io.Reader, stationID string) (*BuoyObservation, error) {
+// Observation times are in UTC and are converted to loc.
+func parseBuoyData(r io.Reader, stationID string, loc *time.Location) (*BuoyObservation, error) {
 	scanner := bufio.NewScanner(r)
 
 	// Skip the two header rows
@@ -83,9 +289,14 @@ func parseBuoyData(r io.Reader, stationID string) (*BuoyObservation, error) {
 			continue
 		}
 
+		observed, err := time.ParseInLocation(ndbcTimeFormat, strings.Join(fields[:5], " "), time.UTC)
+		if err != nil {
+			continue
+		}
+
 		obs := &BuoyObservation{
 			StationID:        stationID,
-			ObservationTime:  fmt.Sprintf("%s-%s-%s %s:%s", fields[0], fields[1], fields[2], fields[3], fields[4]),
+			ObservationTime:  observed.In(loc).Format(time.RFC3339),
 			WindDirectionDeg: parseNdbcFloat(fields[5]),
 			WindSpeedMph:     metersPerSecToMph(parseNdbcFloat(fields[6])),
 			GustSpeedMph:     metersPerSecToMph(parseNdbcFloat(fields[7])),
@@ -1,8 +1,13 @@
 package weather
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/louislef299/wave-report-agent/pkg/spot"
 )
@@ -50,13 +55,14 @@ func TestGetBuoyObservations(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(fmt.Sprintf("%s (%s)", tt.spot.Name, tt.spot.NearestBuoyID), func(t *testing.T) {
-			obs, err := GetBuoyObservations(nil, tt.spot)
+			resp, err := GetBuoyObservations(nil, tt.spot)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if obs == nil {
+			if resp == nil || resp.Blended == nil {
 				t.Fatal("expected observation, got nil")
 			}
+			obs := resp.Blended
 
 			// All station types should report at least wind speed.
 			// Wind direction may be MM on some shore stations (e.g. pressure-only sensors).
@@ -73,3 +79,135 @@ func TestGetBuoyObservations(t *testing.T) {
 		})
 	}
 }
+
+func TestBlendObservations(t *testing.T) {
+	stations := []spot.BuoyStation{
+		{ID: "AAAAA", Role: spot.RoleWaves, Weight: 3},
+		{ID: "BBBBB", Role: spot.RoleWaves, Weight: 1},
+		{ID: "CCCCC", Role: spot.RoleWind, Weight: 1},
+		{ID: "DDDDD", Role: spot.RoleUpstreamSwell, Weight: 1},
+	}
+
+	testCases := []struct {
+		name     string
+		observed []*BuoyObservation
+		expected *BuoyObservation
+	}{
+		{
+			name: "weighted waves and dedicated wind",
+			observed: []*BuoyObservation{
+				{StationID: "AAAAA", WaveHeightFt: 4, DominantPeriodS: 12, MeanWaveDirDeg: 350, WindSpeedMph: 20, GustSpeedMph: -1, WindDirectionDeg: 90, WaterTempC: 14, ObservationTime: "2026-01-01T10:00:00-08:00"},
+				{StationID: "BBBBB", WaveHeightFt: 8, DominantPeriodS: 16, MeanWaveDirDeg: 30, WindSpeedMph: 20, GustSpeedMph: -1, WindDirectionDeg: 90, WaterTempC: 18, ObservationTime: "2026-01-01T10:10:00-08:00"},
+				{StationID: "CCCCC", WaveHeightFt: -1, DominantPeriodS: -1, MeanWaveDirDeg: -1, WindSpeedMph: 10, GustSpeedMph: 15, WindDirectionDeg: 270, WaterTempC: -1, ObservationTime: "2026-01-01T09:50:00-08:00"},
+				{StationID: "DDDDD", WaveHeightFt: 20, DominantPeriodS: 20, MeanWaveDirDeg: 180, WindSpeedMph: 40, GustSpeedMph: 50, WindDirectionDeg: 180, WaterTempC: 5, ObservationTime: "2026-01-01T11:00:00-08:00"},
+			},
+			expected: &BuoyObservation{StationID: "AAAAA,BBBBB,CCCCC", WaveHeightFt: 5, DominantPeriodS: 13, MeanWaveDirDeg: 0, WindSpeedMph: 10, GustSpeedMph: 15, WindDirectionDeg: 270, WaterTempC: 15, ObservationTime: "2026-01-01T10:10:00-08:00", WaterTempTime: "2026-01-01T10:00:00-08:00"},
+		},
+		{
+			name: "water temperature dated by the stations that report it",
+			observed: []*BuoyObservation{
+				{StationID: "AAAAA", WaveHeightFt: 4, DominantPeriodS: 12, MeanWaveDirDeg: 270, WindSpeedMph: -1, GustSpeedMph: -1, WindDirectionDeg: -1, WaterTempC: 14, ObservationTime: "2026-01-01T04:00:00-08:00"},
+				nil,
+				{StationID: "CCCCC", WaveHeightFt: -1, DominantPeriodS: -1, MeanWaveDirDeg: -1, WindSpeedMph: 10, GustSpeedMph: 15, WindDirectionDeg: 270, WaterTempC: -1, ObservationTime: "2026-01-01T10:00:00-08:00"},
+				nil,
+			},
+			expected: &BuoyObservation{StationID: "AAAAA,CCCCC", WaveHeightFt: 4, DominantPeriodS: 12, MeanWaveDirDeg: 270, WindSpeedMph: 10, GustSpeedMph: 15, WindDirectionDeg: 270, WaterTempC: 14, ObservationTime: "2026-01-01T10:00:00-08:00", WaterTempTime: "2026-01-01T04:00:00-08:00"},
+		},
+		{
+			name: "wind falls back to wave stations",
+			observed: []*BuoyObservation{
+				{StationID: "AAAAA", WaveHeightFt: 4, DominantPeriodS: -1, MeanWaveDirDeg: 270, WindSpeedMph: 8, GustSpeedMph: -1, WindDirectionDeg: 0, WaterTempC: -1, ObservationTime: "2026-01-01T10:00:00-08:00"},
+				nil,
+				nil,
+				nil,
+			},
+			expected: &BuoyObservation{StationID: "AAAAA", WaveHeightFt: 4, DominantPeriodS: -1, MeanWaveDirDeg: 270, WindSpeedMph: 8, GustSpeedMph: -1, WindDirectionDeg: 0, WaterTempC: -1, ObservationTime: "2026-01-01T10:00:00-08:00"},
+		},
+		{
+			name:     "nothing reported",
+			observed: []*BuoyObservation{nil, nil, nil, nil},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			observed := make([]StationObservation, len(tt.observed))
+			for i, obs := range tt.observed {
+				observed[i] = StationObservation{ID: stations[i].ID, Role: stations[i].Role, Observation: obs}
+			}
+
+			blended := blendObservations(stations, observed)
+			if (blended == nil) != (tt.expected == nil) || (blended != nil && *blended != *tt.expected) {
+				t.Fatalf("Blended observation did not match expected:\n\tReturned: %+v\n\tExpected: %+v", blended, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseBuoyDataLocalTime(t *testing.T) {
+	data := `#YY  MM DD hh mm WDIR WSPD GST  WVHT   DPD   APD MWD   PRES  ATMP  WTMP  DEWP  VIS PTDY  TIDE
+#yr  mo dy hr mn degT m/s  m/s     m   sec   sec degT   hPa  degC  degC  degC  nmi  hPa    ft
+2026 10 17 03 50 290  5.0  7.0   1.5  14.0   8.0 280 1015.0  15.0  18.5  12.0   MM   MM    MM
+`
+	testCases := []struct {
+		tz       string
+		expected string
+	}{
+		{tz: "America/Los_Angeles", expected: "2026-10-16T20:50:00-07:00"},
+		{tz: "America/Chicago", expected: "2026-10-16T22:50:00-05:00"},
+		{tz: "UTC", expected: "2026-10-17T03:50:00Z"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.tz, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.tz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			obs, err := parseBuoyData(strings.NewReader(data), "46086", loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if obs.ObservationTime != tt.expected {
+				t.Fatalf("Returned time did not match expected time:\n\tReturned: %s\n\tExpected: %s", obs.ObservationTime, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetBuoyObservationsPartial(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/46042.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `#YY  MM DD hh mm WDIR WSPD GST  WVHT   DPD   APD MWD   PRES  ATMP  WTMP  DEWP  VIS PTDY  TIDE
+#yr  mo dy hr mn degT m/s  m/s     m   sec   sec degT   hPa  degC  degC  degC  nmi  hPa    ft
+2026 10 17 03 50 290  5.0  7.0   1.5  14.0   8.0 280 1015.0  15.0  18.5  12.0   MM   MM    MM
+`)
+	}))
+	t.Cleanup(srv.Close)
+	orig := ndbcRealtimeUrl
+	ndbcRealtimeUrl = srv.URL
+	t.Cleanup(func() { ndbcRealtimeUrl = orig })
+
+	// Only the upstream swell station reads, so there is nothing to blend but
+	// its reading is still returned.
+	s := &spot.Spot{Name: "Ocean Beach", Timezone: "America/Los_Angeles", Buoys: []spot.BuoyStation{
+		{ID: "46026", Role: spot.RoleWaves, Weight: 1},
+		{ID: "46042", Role: spot.RoleUpstreamSwell, Weight: 1},
+	}}
+	resp, err := GetBuoyObservations(nil, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Blended != nil || len(resp.Stations) != 2 || resp.Stations[0].Error == "" || resp.Stations[1].Observation == nil {
+		t.Fatalf("Expected the failed station's error and the upstream reading without a blend, got %+v", resp)
+	}
+
+	s.Buoys = s.Buoys[:1]
+	if _, err := GetBuoyObservations(nil, s); !errors.Is(err, ErrInvalidHttpResponse) {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrInvalidHttpResponse)
+	}
+}
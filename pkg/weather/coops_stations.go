@@ -0,0 +1,330 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	coopsMetadataUrl = "https://api.tidesandcurrents.noaa.gov/mdapi/prod/webapi"
+
+	// TideStationHarmonic marks a CO-OPS station with its own harmonic
+	// constituents.
+	TideStationHarmonic = "R"
+	// TideStationSubordinate marks a CO-OPS station whose predictions are
+	// derived from a harmonic reference station via time and height offsets.
+	TideStationSubordinate = "S"
+
+	// maxTideStationMiles is the furthest a tide station may be from a spot to
+	// be used automatically.
+	maxTideStationMiles = 50
+)
+
+//go:embed data/coops_tide_stations.json
+var bundledCoopsStations []byte
+
+// TideOffsets converts a reference station's high/low predictions into a
+// subordinate station's. Time offsets are in minutes. Height offsets are
+// multiplied with the reference height when HeightAdjustedType is "R" (ratio)
+// and added to it when "F" (fixed, in feet).
+type TideOffsets struct {
+	RefStationID         string  `json:"refStationId"`
+	HeightAdjustedType   string  `json:"heightAdjustedType"`
+	HeightOffsetHighTide float64 `json:"heightOffsetHighTide"`
+	HeightOffsetLowTide  float64 `json:"heightOffsetLowTide"`
+	TimeOffsetHighTide   float64 `json:"timeOffsetHighTide"`
+	TimeOffsetLowTide    float64 `json:"timeOffsetLowTide"`
+}
+
+// apply adjusts a reference station's prediction for the subordinate station.
+func (o *TideOffsets) apply(p TidePrediction) (TidePrediction, error) {
+	heightOffset, timeOffset := o.HeightOffsetLowTide, o.TimeOffsetLowTide
+	if p.Type == "H" {
+		heightOffset, timeOffset = o.HeightOffsetHighTide, o.TimeOffsetHighTide
+	}
+
+	t, err := parseCoopsTime(p.Time)
+	if err != nil {
+		return p, err
+	}
+	p.Time = t.Add(time.Duration(timeOffset) * time.Minute).Format(coopsTimeFormat)
+
+	if o.HeightAdjustedType == "F" {
+		p.HeightFt += heightOffset
+	} else {
+		p.HeightFt *= heightOffset
+	}
+	p.HeightFt = math.Round(p.HeightFt*100) / 100
+	return p, nil
+}
+
+// TideStation is a CO-OPS station that supports tide predictions.
+type TideStation struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	State       string       `json:"state"`
+	Lat         float64      `json:"lat"`
+	Lon         float64      `json:"lng"`
+	Type        string       `json:"type"`
+	ReferenceID string       `json:"reference_id"`
+	Offsets     *TideOffsets `json:"tidepredoffsets,omitempty"`
+}
+
+// Subordinate reports whether the station's predictions come from a reference
+// station.
+func (s TideStation) Subordinate() bool {
+	return s.Type == TideStationSubordinate
+}
+
+// TideCatalog is a searchable set of CO-OPS tide prediction stations.
+type TideCatalog struct {
+	stations []TideStation
+	byID     map[string]int
+	// live catalogs fetch subordinate offsets on demand; the bundled snapshot
+	// carries them inline.
+	live bool
+	mu   sync.Mutex
+}
+
+type coopsStationList struct {
+	Stations []TideStation `json:"stations"`
+}
+
+// ParseCoopsStations parses the CO-OPS metadata API stations.json format.
+// https://api.tidesandcurrents.noaa.gov/mdapi/prod/
+func ParseCoopsStations(r io.Reader) (*TideCatalog, error) {
+	var raw coopsStationList
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parsing CO-OPS station list: %w", err)
+	}
+
+	c := &TideCatalog{
+		stations: raw.Stations,
+		byID:     make(map[string]int, len(raw.Stations)),
+	}
+	for i, s := range raw.Stations {
+		c.byID[s.ID] = i
+	}
+	return c, nil
+}
+
+var (
+	coopsCatalogOnce sync.Once
+	coopsCatalog     *TideCatalog
+
+	liveCoopsMu      sync.Mutex
+	liveCoopsCatalog *TideCatalog
+	liveCoopsFailed  time.Time
+)
+
+// BundledTideCatalog returns the tide station snapshot compiled into the
+// binary.
+func BundledTideCatalog() *TideCatalog {
+	coopsCatalogOnce.Do(func() {
+		c, err := ParseCoopsStations(bytes.NewReader(bundledCoopsStations))
+		if err != nil {
+			panic(err)
+		}
+		coopsCatalog = c
+	})
+	return coopsCatalog
+}
+
+// FetchTideCatalog downloads the current list of CO-OPS tide prediction
+// stations.
+func FetchTideCatalog(ctx context.Context) (*TideCatalog, error) {
+	var c *TideCatalog
+	err := getCoopsMetadata(ctx, "/stations.json?type=tidepredictions", func(r io.Reader) error {
+		var err error
+		c, err = ParseCoopsStations(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.live = true
+	return c, nil
+}
+
+// loadTideCatalog prefers the live station list, fetched once per process, and
+// falls back to the bundled snapshot when CO-OPS can't be reached. A failed
+// fetch isn't retried until catalogRetryBackoff has passed.
+func loadTideCatalog(ctx context.Context) *TideCatalog {
+	liveCoopsMu.Lock()
+	defer liveCoopsMu.Unlock()
+
+	if liveCoopsCatalog != nil {
+		return liveCoopsCatalog
+	}
+	if ctx == nil || time.Since(liveCoopsFailed) < catalogRetryBackoff {
+		return BundledTideCatalog()
+	}
+	c, err := FetchTideCatalog(ctx)
+	if err != nil {
+		liveCoopsFailed = time.Now()
+		return BundledTideCatalog()
+	}
+	liveCoopsCatalog = c
+	return c
+}
+
+// Lookup returns the station with the given ID.
+func (c *TideCatalog) Lookup(id string) (TideStation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.byID[id]
+	if !ok {
+		return TideStation{}, false
+	}
+	return c.stations[i], true
+}
+
+// Nearest returns the closest tide prediction station to a coordinate and its
+// distance in miles. ok is false when the catalog is empty.
+func (c *TideCatalog) Nearest(lat, lon float64) (st TideStation, miles float64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	miles = math.Inf(1)
+	for _, s := range c.stations {
+		if d := spot.DistanceMiles(lat, lon, s.Lat, s.Lon); d < miles {
+			st, miles, ok = s, d, true
+		}
+	}
+	return st, miles, ok
+}
+
+// offsets returns the subordinate station's prediction offsets, fetching and
+// caching them from CO-OPS for live catalogs.
+func (c *TideCatalog) offsets(ctx context.Context, id string) (*TideOffsets, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown tide station %s", id)
+	}
+	if o := c.stations[i].Offsets; o != nil || !c.live {
+		return o, nil
+	}
+
+	var o TideOffsets
+	err := getCoopsMetadata(ctx, fmt.Sprintf("/stations/%s/tidepredoffsets.json", id), func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&o)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching offsets for tide station %s: %w", id, err)
+	}
+	c.stations[i].Offsets = &o
+	return &o, nil
+}
+
+func getCoopsMetadata(ctx context.Context, path string, decode func(io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", coopsMetadataUrl+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrInvalidHttpResponse
+	}
+	return decode(resp.Body)
+}
+
+// TideStationSuggestion describes the tide station chosen for a spot.
+type TideStationSuggestion struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Type          string  `json:"type" jsonschema_description:"'harmonic' for stations with their own harmonic constituents, 'subordinate' for stations derived from a reference station with time and height offsets."`
+	ReferenceID   string  `json:"reference_id,omitempty" jsonschema_description:"Harmonic reference station for subordinate stations."`
+	DistanceMiles float64 `json:"distance_miles"`
+}
+
+// TideStationReport checks a spot's configured tide station and suggests the
+// nearest prediction-capable station.
+type TideStationReport struct {
+	Spot         string                 `json:"spot"`
+	ConfiguredID string                 `json:"configured_id" jsonschema_description:"The spot's current tide_station_id. Empty when unset."`
+	Nearest      *TideStationSuggestion `json:"nearest" jsonschema_description:"Nearest tide prediction station. Null when the catalog is empty."`
+	Warnings     []string               `json:"warnings" jsonschema_description:"Problems with the configured station. Empty when it looks fine."`
+}
+
+// CheckTideStation reports whether the spot's configured tide station supports
+// predictions and which station is nearest.
+func (c *TideCatalog) CheckTideStation(s *spot.Spot) TideStationReport {
+	r := TideStationReport{
+		Spot:         s.Name,
+		ConfiguredID: s.TideStationID,
+		Warnings:     []string{},
+	}
+	if st, d, ok := c.Nearest(float64(s.Latitude), float64(s.Longitude)); ok {
+		r.Nearest = newTideSuggestion(st, d)
+	}
+
+	if s.TideStationID == "" {
+		if s.SpotType == spot.SpotTypeOcean {
+			r.Warnings = append(r.Warnings, "no tide station configured, the nearest station will be used")
+		}
+		return r
+	}
+
+	st, ok := c.Lookup(s.TideStationID)
+	if !ok {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("station %s does not provide tide predictions", s.TideStationID))
+		return r
+	}
+	if d := s.DistanceTo(st.Lat, st.Lon); d > maxTideStationMiles {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("station %s is %.0f miles from the spot", st.ID, d))
+	}
+	return r
+}
+
+func newTideSuggestion(st TideStation, miles float64) *TideStationSuggestion {
+	sug := &TideStationSuggestion{
+		ID:            st.ID,
+		Name:          st.Name,
+		Type:          "harmonic",
+		DistanceMiles: math.Round(miles*10) / 10,
+	}
+	if st.Subordinate() {
+		sug.Type = "subordinate"
+		sug.ReferenceID = st.ReferenceID
+	}
+	return sug
+}
+
+// SuggestTideStation checks the spot's configured tide station and suggests
+// the nearest CO-OPS tide prediction station.
+func SuggestTideStation(ctx tool.Context, s *spot.Spot) (*TideStationReport, error) {
+	r := loadTideCatalog(ctx).CheckTideStation(s)
+	return &r, nil
+}
+
+// CheckTideStations checks every spot's configured tide station against the
+// CO-OPS station catalog.
+func CheckTideStations(ctx context.Context, spots []spot.Spot) []TideStationReport {
+	c := loadTideCatalog(ctx)
+	reports := make([]TideStationReport, 0, len(spots))
+	for i := range spots {
+		reports = append(reports, c.CheckTideStation(&spots[i]))
+	}
+	return reports
+}
@@ -0,0 +1,118 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestTideOffsetsApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		offsets  TideOffsets
+		in       TidePrediction
+		expected TidePrediction
+	}{
+		{
+			name:     "ratio high tide",
+			offsets:  TideOffsets{HeightAdjustedType: "R", HeightOffsetHighTide: 0.9, TimeOffsetHighTide: -17},
+			in:       TidePrediction{Time: "2026-10-17 10:05", HeightFt: 5, Type: "H"},
+			expected: TidePrediction{Time: "2026-10-17 09:48", HeightFt: 4.5, Type: "H"},
+		},
+		{
+			name:     "fixed low tide across midnight",
+			offsets:  TideOffsets{HeightAdjustedType: "F", HeightOffsetLowTide: -0.2, TimeOffsetLowTide: 38},
+			in:       TidePrediction{Time: "2026-10-17 23:40", HeightFt: 0.5, Type: "L"},
+			expected: TidePrediction{Time: "2026-10-18 00:18", HeightFt: 0.3, Type: "L"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.offsets.apply(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Fatalf("Returned prediction did not match:\n\tReturned: %+v\n\tExpected: %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckTideStation(t *testing.T) {
+	testCases := []struct {
+		spot            *spot.Spot
+		expectedNearest string
+		expectedType    string
+		expectWarn      bool
+	}{
+		{
+			spot: &spot.Spot{
+				Name:          "Rincon Point",
+				Latitude:      34.3728477,
+				Longitude:     -119.4984414,
+				SpotType:      spot.SpotTypeOcean,
+				TideStationID: "9411340",
+			},
+			expectedNearest: "9411270",
+			expectedType:    "subordinate",
+		},
+		{
+			spot: &spot.Spot{
+				Name:      "Stinson Beach",
+				Latitude:  37.8991,
+				Longitude: -122.6436,
+				SpotType:  spot.SpotTypeOcean,
+			},
+			expectedNearest: "9414958",
+			expectedType:    "subordinate",
+			expectWarn:      true,
+		},
+		{
+			spot: &spot.Spot{
+				Name:          "Ocean Beach",
+				Latitude:      32.7487318,
+				Longitude:     -117.2583427,
+				SpotType:      spot.SpotTypeOcean,
+				TideStationID: "0000000",
+			},
+			expectedNearest: "9410170",
+			expectedType:    "harmonic",
+			expectWarn:      true,
+		},
+	}
+
+	c := BundledTideCatalog()
+	for _, tt := range testCases {
+		t.Run(tt.spot.Name, func(t *testing.T) {
+			r := c.CheckTideStation(tt.spot)
+			if r.Nearest == nil || r.Nearest.ID != tt.expectedNearest || r.Nearest.Type != tt.expectedType {
+				t.Fatalf("Expected nearest %s station %s, got %+v", tt.expectedType, tt.expectedNearest, r.Nearest)
+			}
+			if got := len(r.Warnings) > 0; got != tt.expectWarn {
+				t.Fatalf("Expected warnings=%v, got %v", tt.expectWarn, r.Warnings)
+			}
+		})
+	}
+}
+
+func TestLoadTideCatalogBacksOff(t *testing.T) {
+	t.Cleanup(func() { liveCoopsFailed = time.Time{} })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if c := loadTideCatalog(ctx); c != BundledTideCatalog() {
+		t.Fatal("expected the bundled catalog when the live fetch fails")
+	}
+	failed := liveCoopsFailed
+	if failed.IsZero() {
+		t.Fatal("expected the failed fetch to be remembered")
+	}
+
+	if c := loadTideCatalog(ctx); c != BundledTideCatalog() || !liveCoopsFailed.Equal(failed) {
+		t.Fatal("expected the live fetch not to be retried within the backoff")
+	}
+}
@@ -0,0 +1,320 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+// GridPointCacheEnv names the file NWS gridpoint lookups are persisted to.
+// Defaults to nws_gridpoints.json in the user cache directory.
+const GridPointCacheEnv = "WAVE_GRIDPOINT_CACHE"
+
+const (
+	// warmConcurrency bounds concurrent /points lookups so startup stays
+	// polite to the NWS API.
+	warmConcurrency = 4
+
+	// marineZoneOffsetMiles is how far seaward of the spot to look for a
+	// marine zone when the beach itself falls in a land zone.
+	marineZoneOffsetMiles = 3
+)
+
+// errGridPointMoved reports that NWS redirected or no longer serves a cached
+// gridpoint URL.
+var errGridPointMoved = errors.New("NWS gridpoint moved")
+
+// GridPoint holds the NWS endpoints for a location, as returned by
+// https://api.weather.gov/points.
+type GridPoint struct {
+	Office           string    `json:"office"`
+	GridX            int       `json:"grid_x"`
+	GridY            int       `json:"grid_y"`
+	Forecast         string    `json:"forecast"`
+	ForecastHourly   string    `json:"forecast_hourly"`
+	ForecastGridData string    `json:"forecast_grid_data"`
+	ForecastZone     string    `json:"forecast_zone,omitempty"`
+	MarineZone       string    `json:"marine_zone,omitempty"`
+	Fetched          time.Time `json:"fetched"`
+}
+
+// GridPointCache persists NWS gridpoint lookups keyed by coordinates rounded
+// to the precision used for /points requests. Entries are refreshed when NWS
+// redirects or 404s one of their URLs, which happens when forecast offices
+// re-grid.
+type GridPointCache struct {
+	mu      sync.Mutex
+	path    string
+	base    string
+	entries map[string]*GridPoint
+}
+
+type pointsResp struct {
+	Properties struct {
+		GridID           string `json:"gridId"`
+		GridX            int    `json:"gridX"`
+		GridY            int    `json:"gridY"`
+		Forecast         string `json:"forecast"`
+		ForecastHourly   string `json:"forecastHourly"`
+		ForecastGridData string `json:"forecastGridData"`
+		ForecastZone     string `json:"forecastZone"`
+	} `json:"properties"`
+}
+
+type zonesResp struct {
+	Features []struct {
+		ID string `json:"id"`
+	} `json:"features"`
+}
+
+// nwsProductClient doesn't follow redirects so moved gridpoints can be
+// detected and re-resolved.
+var nwsProductClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// NewGridPointCache returns a cache persisted to path, loading any existing
+// entries. An empty path keeps the cache in memory only.
+func NewGridPointCache(path string) *GridPointCache {
+	c := &GridPointCache{
+		path:    path,
+		base:    nwsBaseUrl,
+		entries: make(map[string]*GridPoint),
+	}
+	if path == "" {
+		return c
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(b, &c.entries); err != nil {
+		log.Printf("Ignoring unreadable NWS gridpoint cache %s: %v", path, err)
+		c.entries = make(map[string]*GridPoint)
+	}
+	return c
+}
+
+var defaultGridPoints = sync.OnceValue(func() *GridPointCache {
+	return NewGridPointCache(gridPointCachePath())
+})
+
+func gridPointCachePath() string {
+	if path := os.Getenv(GridPointCacheEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wave-report-agent", "nws_gridpoints.json")
+}
+
+func gridPointKey(s *spot.Spot) string {
+	return fmt.Sprintf("%.2f,%.2f", s.Latitude, s.Longitude)
+}
+
+// Get returns the spot's cached gridpoint, looking it up on a miss.
+func (c *GridPointCache) Get(ctx context.Context, s *spot.Spot) (*GridPoint, error) {
+	c.mu.Lock()
+	gp, ok := c.entries[gridPointKey(s)]
+	c.mu.Unlock()
+	if ok {
+		return gp, nil
+	}
+	return c.Refresh(ctx, s)
+}
+
+// Refresh looks up the spot's gridpoint from NWS and replaces the cached
+// entry.
+func (c *GridPointCache) Refresh(ctx context.Context, s *spot.Spot) (*GridPoint, error) {
+	gp, err := c.lookup(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[gridPointKey(s)] = gp
+	if err := c.save(); err != nil {
+		log.Printf("Failed to persist NWS gridpoint cache: %v", err)
+	}
+	return gp, nil
+}
+
+// Warm concurrently resolves the gridpoint of every spot missing from the
+// cache.
+func (c *GridPointCache) Warm(ctx context.Context, spots []spot.Spot) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, warmConcurrency)
+	)
+	for i := range spots {
+		s := &spots[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if _, err := c.Get(ctx, s); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", s.Name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// fetch GETs one of the spot's gridpoint URLs, chosen by product. When NWS
+// redirects or 404s the cached URL, the gridpoint is looked up again and the
+// request retried once.
+func (c *GridPointCache) fetch(ctx context.Context, s *spot.Spot, product func(*GridPoint) string) ([]byte, error) {
+	gp, err := c.Get(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := getNwsProduct(ctx, product(gp))
+	if !errors.Is(err, errGridPointMoved) {
+		return body, err
+	}
+
+	if gp, err = c.Refresh(ctx, s); err != nil {
+		return nil, err
+	}
+	return getNwsProduct(ctx, product(gp))
+}
+
+func getNwsProduct(ctx context.Context, u string) ([]byte, error) {
+	if u == "" {
+		return nil, fmt.Errorf("NWS does not provide this product for the location: %w", ErrInvalidHttpResponse)
+	}
+
+	req, err := generateNwsReq(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := nwsProductClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return nil, fmt.Errorf("%s returned %d: %w", u, resp.StatusCode, errGridPointMoved)
+	case resp.StatusCode != http.StatusOK:
+		return nil, ErrInvalidHttpResponse
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// lookup resolves the spot's gridpoint, public forecast zone and marine zone
+// from the NWS API.
+func (c *GridPointCache) lookup(ctx context.Context, s *spot.Spot) (*GridPoint, error) {
+	var points pointsResp
+	if err := c.getJSON(ctx, fmt.Sprintf("points/%.2f,%.2f", s.Latitude, s.Longitude), &points); err != nil {
+		return nil, err
+	}
+
+	p := points.Properties
+	gp := &GridPoint{
+		Office:           p.GridID,
+		GridX:            p.GridX,
+		GridY:            p.GridY,
+		Forecast:         p.Forecast,
+		ForecastHourly:   p.ForecastHourly,
+		ForecastGridData: p.ForecastGridData,
+		ForecastZone:     p.ForecastZone,
+		Fetched:          time.Now().UTC(),
+	}
+
+	// Beaches usually sit just inside a land zone, so fall back to a point
+	// offshore along the direction the spot faces.
+	lat, lon := float64(s.Latitude), float64(s.Longitude)
+	offLat, offLon := spot.Destination(lat, lon, s.Facing, marineZoneOffsetMiles)
+	for _, pt := range [][2]float64{{lat, lon}, {offLat, offLon}} {
+		var zones zonesResp
+		if err := c.getJSON(ctx, fmt.Sprintf("zones?type=marine&point=%.4f,%.4f", pt[0], pt[1]), &zones); err != nil {
+			continue
+		}
+		if len(zones.Features) > 0 {
+			gp.MarineZone = zones.Features[0].ID
+			break
+		}
+	}
+	return gp, nil
+}
+
+func (c *GridPointCache) getJSON(ctx context.Context, path string, v any) error {
+	req, err := generateNwsReq(ctx, c.base+"/"+path)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrInvalidHttpResponse
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// save atomically writes the cache to disk. Callers must hold c.mu.
+func (c *GridPointCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".nws_gridpoints-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// WarmGridPoints resolves and persists the NWS gridpoint of every spot not
+// already in the default cache.
+func WarmGridPoints(ctx context.Context, spots []spot.Spot) error {
+	return defaultGridPoints().Warm(ctx, spots)
+}
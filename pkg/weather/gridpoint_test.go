@@ -0,0 +1,137 @@
+package weather
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+// fakeNws serves /points lookups whose forecast office changes after the
+// first call, and 301s the original office's forecast like a re-gridded NWS
+// office does.
+func fakeNws(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var lookups atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/points/"):
+			office := "OLD"
+			if lookups.Add(1) > 1 {
+				office = "NEW"
+			}
+			fmt.Fprintf(w, `{"properties": {"gridId": %q, "gridX": 1, "gridY": 2, "forecast": "%s/gridpoints/%s/1,2/forecast"}}`, office, srv.URL, office)
+		case r.URL.Path == "/zones":
+			fmt.Fprintf(w, `{"features": [{"id": "%s/zones/marine/LMZ346"}]}`, srv.URL)
+		case r.URL.Path == "/gridpoints/OLD/1,2/forecast":
+			http.Redirect(w, r, "/gridpoints/NEW/1,2/forecast", http.StatusMovedPermanently)
+		case r.URL.Path == "/gridpoints/NEW/1,2/forecast":
+			fmt.Fprint(w, `{"properties": {"periods": []}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &lookups
+}
+
+func TestGridPointCacheRefreshOnMove(t *testing.T) {
+	srv, lookups := fakeNws(t)
+	path := filepath.Join(t.TempDir(), "gridpoints.json")
+	s := &spot.Spot{Name: "Empire Beach", Latitude: 44.8120363, Longitude: -86.1093288, Facing: 270}
+
+	c := NewGridPointCache(path)
+	c.base = srv.URL
+
+	body, err := c.fetch(t.Context(), s, func(gp *GridPoint) string { return gp.Forecast })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(body), "periods") {
+		t.Fatalf("expected the forecast body, got %s", body)
+	}
+	if n := lookups.Load(); n != 2 {
+		t.Fatalf("Expected 2 /points lookups after a redirect, got %d", n)
+	}
+
+	// The refreshed entry is persisted and reused.
+	reloaded := NewGridPointCache(path)
+	reloaded.base = srv.URL
+	gp, err := reloaded.Get(t.Context(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gp.Office != "NEW" || !strings.HasSuffix(gp.MarineZone, "LMZ346") {
+		t.Fatalf("Expected persisted NEW gridpoint with marine zone, got %+v", gp)
+	}
+	if n := lookups.Load(); n != 2 {
+		t.Fatalf("Expected cached gridpoint to be reused, got %d lookups", n)
+	}
+}
+
+func TestGridPointCacheWarm(t *testing.T) {
+	srv, lookups := fakeNws(t)
+	c := NewGridPointCache("")
+	c.base = srv.URL
+
+	spots := []spot.Spot{
+		{Name: "Empire Beach", Latitude: 44.8120363, Longitude: -86.1093288},
+		{Name: "Stoney Point", Latitude: 46.9666696, Longitude: -91.6359906},
+		// Rounds to the same gridpoint key as Empire Beach.
+		{Name: "Empire Beach North", Latitude: 44.8149, Longitude: -86.1081},
+	}
+	if err := c.Warm(t.Context(), spots); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.entries) != 2 {
+		t.Fatalf("Expected 2 cached gridpoints, got %d", len(c.entries))
+	}
+
+	before := lookups.Load()
+	if err := c.Warm(t.Context(), spots); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := lookups.Load(); n != before {
+		t.Fatalf("Expected a warm cache to skip lookups, got %d new", n-before)
+	}
+}
+
+func TestGridPointLookupSeawardAfterZonesError(t *testing.T) {
+	var zoneQueries atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/points/44.81,-86.11":
+			fmt.Fprint(w, `{"properties": {"gridId": "APX", "gridX": 1, "gridY": 2}}`)
+		case "/zones":
+			if zoneQueries.Add(1) == 1 {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprintf(w, `{"features": [{"id": "%s/zones/marine/LMZ346"}]}`, srv.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewGridPointCache("")
+	c.base = srv.URL
+	s := &spot.Spot{Name: "Empire Beach", Latitude: 44.8120363, Longitude: -86.1093288, Facing: 270}
+
+	gp, err := c.lookup(t.Context(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(gp.MarineZone, "LMZ346") {
+		t.Fatalf("Expected the seaward point's marine zone after the first query failed, got %q", gp.MarineZone)
+	}
+	if n := zoneQueries.Load(); n != 2 {
+		t.Fatalf("Expected 2 zone queries, got %d", n)
+	}
+}
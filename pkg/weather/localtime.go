@@ -0,0 +1,26 @@
+package weather
+
+import (
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+// Every fetcher reports times as RFC3339 in the spot's own timezone, with the
+// UTC offset included, so readings from different sources line up hour for
+// hour.
+
+// localTime formats t as RFC3339 in the spot's timezone.
+func localTime(s *spot.Spot, t time.Time) string {
+	return t.In(s.Location()).Format(time.RFC3339)
+}
+
+// localizeTimestamp re-expresses an RFC3339 timestamp carrying its own offset
+// in the spot's timezone. Unparseable timestamps are returned unchanged.
+func localizeTimestamp(s *spot.Spot, ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return localTime(s, t)
+}
@@ -2,9 +2,14 @@ package weather
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/louislef299/wave-report-agent/pkg/spot"
 	"google.golang.org/adk/tool"
@@ -12,9 +17,29 @@ import (
 
 // https://open-meteo.com/en/docs/marine-weather-api#data_sources
 
+// openMeteoTimeFormat is the layout of Open-Meteo's iso8601 times, which are
+// local to the requested timezone and carry no offset.
+const openMeteoTimeFormat = "2006-01-02T15:04"
+
+// marineBaseUrl is the Open-Meteo marine endpoint, swapped out in tests.
+var marineBaseUrl = "https://marine-api.open-meteo.com/v1/marine"
+
+// marineOffsetMiles are the distances seaward of the spot, along its facing
+// direction, tried in turn until the marine grid cell has wave data. Spots on
+// the beach often fall in a land cell of the coarse marine grid.
+var marineOffsetMiles = []float64{0, 2, 5, 10, 20}
+
+var ErrNoMarineData = errors.New("no marine forecast data near the spot")
+
+// OpenMeteoResp is the hourly marine forecast as one record per hour.
 type OpenMeteoResp struct {
-	HourlyUnits HourlyUnits `json:"hourly_units"`
-	Hourly      Hourly      `json:"hourly"`
+	Latitude      float64        `json:"latitude" jsonschema_description:"Latitude of the marine grid cell the forecast is for."`
+	Longitude     float64        `json:"longitude" jsonschema_description:"Longitude of the marine grid cell the forecast is for."`
+	OffshoreMiles float64        `json:"offshore_miles" jsonschema_description:"How far seaward of the spot, along its facing direction, the forecast was requested because the spot's own grid cell is land. 0 when the spot's cell has wave data."`
+	Timezone      string         `json:"timezone" jsonschema_description:"IANA timezone of the hourly times, which are RFC3339 with the UTC offset included."`
+	HourlyUnits   HourlyUnits    `json:"hourly_units"`
+	Hours         MarineHours    `json:"hours" jsonschema_description:"One record per forecast hour, in time order."`
+	Missing       map[string]int `json:"missing" jsonschema_description:"Number of hours each variable has no value, e.g. because the model cell is land-masked. Only variables with gaps are listed."`
 }
 
 type HourlyUnits struct {
@@ -28,25 +53,145 @@ type HourlyUnits struct {
 	SwellWaveHeight    string `json:"swell_wave_height"`
 	SwellWaveDirection string `json:"swell_wave_direction"`
 	SwellWavePeriod    string `json:"swell_wave_period"`
-	SeaLevelHeightMsl  string `json:"sea_level_height_msl"`
+
+	SecondarySwellWaveHeight    string `json:"secondary_swell_wave_height"`
+	SecondarySwellWaveDirection string `json:"secondary_swell_wave_direction"`
+	SecondarySwellWavePeriod    string `json:"secondary_swell_wave_period"`
+	TertiarySwellWaveHeight     string `json:"tertiary_swell_wave_height"`
+	TertiarySwellWaveDirection  string `json:"tertiary_swell_wave_direction"`
+	TertiarySwellWavePeriod     string `json:"tertiary_swell_wave_period"`
+
+	SeaLevelHeightMsl     string `json:"sea_level_height_msl"`
+	SeaSurfaceTemperature string `json:"sea_surface_temperature"`
+	OceanCurrentVelocity  string `json:"ocean_current_velocity"`
+	OceanCurrentDirection string `json:"ocean_current_direction"`
+}
+
+// MarineHour is the marine forecast for one hour. Values Open-Meteo has no
+// data for are omitted.
+type MarineHour struct {
+	Time               time.Time `json:"time" jsonschema_description:"Start of the hour, RFC3339 in the spot's timezone."`
+	WaveHeight         *float64  `json:"wave_height,omitempty"`
+	WaveDirection      *float64  `json:"wave_direction,omitempty"`
+	WavePeriod         *float64  `json:"wave_period,omitempty"`
+	WindWaveHeight     *float64  `json:"wind_wave_height,omitempty"`
+	WindWaveDirection  *float64  `json:"wind_wave_direction,omitempty"`
+	WindWavePeriod     *float64  `json:"wind_wave_period,omitempty"`
+	SwellWaveHeight    *float64  `json:"swell_wave_height,omitempty"`
+	SwellWaveDirection *float64  `json:"swell_wave_direction,omitempty"`
+	SwellWavePeriod    *float64  `json:"swell_wave_period,omitempty"`
+
+	SecondarySwellWaveHeight    *float64 `json:"secondary_swell_wave_height,omitempty"`
+	SecondarySwellWaveDirection *float64 `json:"secondary_swell_wave_direction,omitempty"`
+	SecondarySwellWavePeriod    *float64 `json:"secondary_swell_wave_period,omitempty"`
+	TertiarySwellWaveHeight     *float64 `json:"tertiary_swell_wave_height,omitempty"`
+	TertiarySwellWaveDirection  *float64 `json:"tertiary_swell_wave_direction,omitempty"`
+	TertiarySwellWavePeriod     *float64 `json:"tertiary_swell_wave_period,omitempty"`
+
+	SeaLevelHeightMsl     *float64 `json:"sea_level_height_msl,omitempty"`
+	SeaSurfaceTemperature *float64 `json:"sea_surface_temperature,omitempty" jsonschema_description:"Sea surface temperature in °C."`
+
+	OceanCurrentVelocity  *float64 `json:"ocean_current_velocity,omitempty" jsonschema_description:"Surface current speed, in hourly_units.ocean_current_velocity."`
+	OceanCurrentDirection *float64 `json:"ocean_current_direction,omitempty" jsonschema_description:"Direction the surface current flows TOWARD, in degrees true."`
+	LongshoreCurrent      *float64 `json:"longshore_current,omitempty" jsonschema_description:"Current component along the beach, in the velocity's unit. Positive flows to the right when looking out to sea from the spot (its facing + 90°), negative to the left."`
+	CrossShoreCurrent     *float64 `json:"cross_shore_current,omitempty" jsonschema_description:"Current component perpendicular to the beach, in the velocity's unit. Positive flows offshore (seaward along the spot's facing), negative onshore."`
+
+	DominantSwell string `json:"dominant_swell" jsonschema_description:"Partition carrying the most wave energy this hour: 'swell' (primary), 'secondary_swell', 'tertiary_swell' or 'wind_wave'. 'none' when every partition is flat or missing."`
+}
+
+// Wave partitions named in MarineHour.DominantSwell.
+const (
+	partitionPrimary   = "swell"
+	partitionSecondary = "secondary_swell"
+	partitionTertiary  = "tertiary_swell"
+	partitionWindWave  = "wind_wave"
+	partitionNone      = "none"
+)
+
+// openMeteoColumn ties an Open-Meteo hourly variable to its field on the
+// hourly record type H.
+type openMeteoColumn[H any] struct {
+	name  string
+	field func(*H) **float64
+}
+
+// marineColumns are the hourly variables requested from Open-Meteo.
+var marineColumns = []openMeteoColumn[MarineHour]{
+	{"wave_height", func(h *MarineHour) **float64 { return &h.WaveHeight }},
+	{"wave_direction", func(h *MarineHour) **float64 { return &h.WaveDirection }},
+	{"wave_period", func(h *MarineHour) **float64 { return &h.WavePeriod }},
+	{"wind_wave_height", func(h *MarineHour) **float64 { return &h.WindWaveHeight }},
+	{"wind_wave_direction", func(h *MarineHour) **float64 { return &h.WindWaveDirection }},
+	{"wind_wave_period", func(h *MarineHour) **float64 { return &h.WindWavePeriod }},
+	{"swell_wave_height", func(h *MarineHour) **float64 { return &h.SwellWaveHeight }},
+	{"swell_wave_direction", func(h *MarineHour) **float64 { return &h.SwellWaveDirection }},
+	{"swell_wave_period", func(h *MarineHour) **float64 { return &h.SwellWavePeriod }},
+	{"secondary_swell_wave_height", func(h *MarineHour) **float64 { return &h.SecondarySwellWaveHeight }},
+	{"secondary_swell_wave_direction", func(h *MarineHour) **float64 { return &h.SecondarySwellWaveDirection }},
+	{"secondary_swell_wave_period", func(h *MarineHour) **float64 { return &h.SecondarySwellWavePeriod }},
+	{"tertiary_swell_wave_height", func(h *MarineHour) **float64 { return &h.TertiarySwellWaveHeight }},
+	{"tertiary_swell_wave_direction", func(h *MarineHour) **float64 { return &h.TertiarySwellWaveDirection }},
+	{"tertiary_swell_wave_period", func(h *MarineHour) **float64 { return &h.TertiarySwellWavePeriod }},
+	{"sea_level_height_msl", func(h *MarineHour) **float64 { return &h.SeaLevelHeightMsl }},
+	{"sea_surface_temperature", func(h *MarineHour) **float64 { return &h.SeaSurfaceTemperature }},
+	{"ocean_current_velocity", func(h *MarineHour) **float64 { return &h.OceanCurrentVelocity }},
+	{"ocean_current_direction", func(h *MarineHour) **float64 { return &h.OceanCurrentDirection }},
 }
 
-type Hourly struct {
-	Time               []string  `json:"time"`
-	WaveHeight         []float32 `json:"wave_height"`
-	WaveDirection      []int32   `json:"wave_direction"`
-	WavePeriod         []float32 `json:"wave_period"`
-	WindWaveHeight     []float32 `json:"wind_wave_height"`
-	WindWaveDirection  []int32   `json:"wind_wave_direction"`
-	WindWavePeriod     []float32 `json:"wind_wave_period"`
-	SwellWaveHeight    []float32 `json:"swell_wave_height"`
-	SwellWaveDirection []int32   `json:"swell_wave_direction"`
-	SwellWavePeriod    []float32 `json:"swell_wave_period"`
-	SeaLevelHeightMsl  []float32 `json:"sea_level_height_msl"`
+// openMeteoMarineResp is Open-Meteo's column-oriented response. Hourly is
+// decoded per variable so that nulls from land-masked cells or missing hours
+// only blank the affected values.
+type openMeteoMarineResp struct {
+	Latitude    float64                    `json:"latitude"`
+	Longitude   float64                    `json:"longitude"`
+	HourlyUnits HourlyUnits                `json:"hourly_units"`
+	Hourly      map[string]json.RawMessage `json:"hourly"`
 }
 
+// GetHourlyMarineForecast fetches the hourly Open-Meteo marine forecast for the
+// spot over Open-Meteo's default horizon, with times in the spot's timezone.
 func GetHourlyMarineForecast(ctx tool.Context, s *spot.Spot) (*OpenMeteoResp, error) {
-	resp, err := http.Get(generateMarineUrl(s))
+	return fetchMarineForecast(s, 0, 0)
+}
+
+// fetchMarineForecast fetches the hourly marine forecast. Zero forecastDays
+// or pastDays leave Open-Meteo's defaults in place. When the spot's grid cell
+// has no wave data, points stepped seaward along the spot's facing are tried.
+func fetchMarineForecast(s *spot.Spot, forecastDays, pastDays int) (*OpenMeteoResp, error) {
+	resp, miles, err := seaward(s, func(lat, lon float64) (*OpenMeteoResp, bool, error) {
+		resp, err := fetchMarineCell(lat, lon, s.Location(), forecastDays, pastDays)
+		if err != nil {
+			return nil, false, err
+		}
+		return resp, resp.Hours.hasWaves(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.OffshoreMiles = miles
+	resp.Hours.resolveCurrents(s.Facing)
+	return resp, nil
+}
+
+// seaward calls fetch at the spot and then at points stepped seaward along its
+// facing until fetch reports the grid cell has wave data, returning the result
+// and how far offshore it was found.
+func seaward[T any](s *spot.Spot, fetch func(lat, lon float64) (T, bool, error)) (T, float64, error) {
+	lat, lon := float64(s.Latitude), float64(s.Longitude)
+	for _, miles := range marineOffsetMiles {
+		v, ok, err := fetch(spot.Destination(lat, lon, s.Facing, miles))
+		if err != nil || ok {
+			return v, miles, err
+		}
+	}
+	var zero T
+	return zero, 0, fmt.Errorf("%s: tried up to %.0f miles offshore: %w", s.Name, marineOffsetMiles[len(marineOffsetMiles)-1], ErrNoMarineData)
+}
+
+// fetchMarineCell fetches the hourly marine forecast of the grid cell
+// containing the coordinate.
+func fetchMarineCell(lat, lon float64, loc *time.Location, forecastDays, pastDays int) (*OpenMeteoResp, error) {
+	resp, err := http.Get(generateMarineUrl(lat, lon, loc, forecastDays, pastDays))
 	if err != nil {
 		return nil, err
 	}
@@ -61,14 +206,142 @@ func GetHourlyMarineForecast(ctx tool.Context, s *spot.Spot) (*OpenMeteoResp, er
 		return nil, err
 	}
 
-	var openResp OpenMeteoResp
-	err = json.Unmarshal(resBody, &openResp)
+	var raw openMeteoMarineResp
+	if err := json.Unmarshal(resBody, &raw); err != nil {
+		return nil, err
+	}
+	return raw.rows(loc)
+}
+
+// rows converts the response into one record per hour with times in loc.
+func (r *openMeteoMarineResp) rows(loc *time.Location) (*OpenMeteoResp, error) {
+	hours, err := openMeteoHours(r.Hourly, marineColumns, loc, func(t time.Time) MarineHour { return MarineHour{Time: t} })
 	if err != nil {
 		return nil, err
 	}
-	return &openResp, nil
+	for i := range hours {
+		hours[i].DominantSwell = hours[i].dominantSwell()
+	}
+
+	return &OpenMeteoResp{
+		Latitude:    r.Latitude,
+		Longitude:   r.Longitude,
+		Timezone:    loc.String(),
+		HourlyUnits: r.HourlyUnits,
+		Hours:       hours,
+		Missing:     MarineHours(hours).Missing(),
+	}, nil
+}
+
+// openMeteoHours converts Open-Meteo's column-oriented hourly block into one
+// record per hour, created by newHour with the hour's start in loc. A column
+// that is missing entirely or holds nulls leaves the affected hours' values
+// unset.
+func openMeteoHours[H any](hourly map[string]json.RawMessage, columns []openMeteoColumn[H], loc *time.Location, newHour func(time.Time) H) ([]H, error) {
+	var times []string
+	if err := json.Unmarshal(hourly["time"], &times); err != nil {
+		return nil, fmt.Errorf("decoding Open-Meteo hourly times: %w", err)
+	}
+
+	values := make([][]*float64, len(columns))
+	for i, c := range columns {
+		if raw, ok := hourly[c.name]; ok {
+			if err := json.Unmarshal(raw, &values[i]); err != nil {
+				return nil, fmt.Errorf("decoding Open-Meteo %s: %w", c.name, err)
+			}
+		}
+	}
+
+	hours := make([]H, 0, len(times))
+	for i, ts := range times {
+		t, err := time.ParseInLocation(openMeteoTimeFormat, ts, loc)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Open-Meteo time %q: %w", ts, err)
+		}
+		h := newHour(t)
+		for j, c := range columns {
+			if i < len(values[j]) {
+				*c.field(&h) = values[j][i]
+			}
+		}
+		hours = append(hours, h)
+	}
+	return hours, nil
+}
+
+// openMeteoVars returns the Open-Meteo variable names of columns.
+func openMeteoVars[H any](columns []openMeteoColumn[H]) []string {
+	vars := make([]string, 0, len(columns))
+	for _, c := range columns {
+		vars = append(vars, c.name)
+	}
+	return vars
+}
+
+// dominantSwell labels the partition with the most energy. Energy scales with
+// height squared times period.
+func (h *MarineHour) dominantSwell() string {
+	partitions := []struct {
+		name           string
+		height, period *float64
+	}{
+		{partitionPrimary, h.SwellWaveHeight, h.SwellWavePeriod},
+		{partitionSecondary, h.SecondarySwellWaveHeight, h.SecondarySwellWavePeriod},
+		{partitionTertiary, h.TertiarySwellWaveHeight, h.TertiarySwellWavePeriod},
+		{partitionWindWave, h.WindWaveHeight, h.WindWavePeriod},
+	}
+
+	dominant, best := partitionNone, 0.0
+	for _, p := range partitions {
+		if p.height == nil || p.period == nil {
+			continue
+		}
+		if e := *p.height * *p.height * *p.period; e > best {
+			dominant, best = p.name, e
+		}
+	}
+	return dominant
+}
+
+// resolveCurrents splits each hour's surface current into components along
+// and across a beach facing the given direction.
+func (hs MarineHours) resolveCurrents(facing spot.Direction) {
+	for i := range hs {
+		h := &hs[i]
+		if h.OceanCurrentVelocity == nil || h.OceanCurrentDirection == nil {
+			continue
+		}
+		rel := (*h.OceanCurrentDirection - facing.Degrees()) * math.Pi / 180
+		along := math.Round(*h.OceanCurrentVelocity*math.Sin(rel)*100) / 100
+		across := math.Round(*h.OceanCurrentVelocity*math.Cos(rel)*100) / 100
+		h.LongshoreCurrent, h.CrossShoreCurrent = &along, &across
+	}
+}
+
+// hasWaves reports whether any hour has a wave height, which land cells of
+// the marine grid never do.
+func (hs MarineHours) hasWaves() bool {
+	for _, h := range hs {
+		if h.WaveHeight != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func generateMarineUrl(lat, lon float64, loc *time.Location, forecastDays, pastDays int) string {
+	u := marineQueryUrl(lat, lon, loc, openMeteoVars(marineColumns))
+	if forecastDays > 0 {
+		u += fmt.Sprintf("&forecast_days=%d", forecastDays)
+	}
+	if pastDays > 0 {
+		u += fmt.Sprintf("&past_days=%d", pastDays)
+	}
+	return u
 }
 
-func generateMarineUrl(s *spot.Spot) string {
-	return fmt.Sprintf("https://marine-api.open-meteo.com/v1/marine?latitude=%.2f&longitude=%.2f&hourly=wave_height,wave_direction,wave_period,wind_wave_height,wind_wave_direction,wind_wave_period,swell_wave_height,swell_wave_direction,swell_wave_period,sea_level_height_msl&length_unit=imperial&wind_speed_unit=kn", s.Latitude, s.Longitude)
+// marineQueryUrl returns the Open-Meteo marine URL for the hourly variables at
+// a coordinate, in imperial units and loc's local time.
+func marineQueryUrl(lat, lon float64, loc *time.Location, vars []string) string {
+	return fmt.Sprintf("%s?latitude=%.2f&longitude=%.2f&hourly=%s&length_unit=imperial&timezone=%s", marineBaseUrl, lat, lon, strings.Join(vars, ","), url.QueryEscape(loc.String()))
 }
@@ -0,0 +1,286 @@
+package weather
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func hourTimes(hs MarineHours) []string {
+	times := make([]string, 0, len(hs))
+	for _, h := range hs {
+		times = append(times, h.Time.Format(time.RFC3339))
+	}
+	return times
+}
+
+func TestOpenMeteoRows(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{
+		"hourly_units": {"time": "iso8601", "wave_height": "ft"},
+		"hourly": {
+			"time": ["2026-03-08T01:00", "2026-03-08T03:00", "2026-10-17T05:00"],
+			"wave_height": [2.1, null, 3.4],
+			"swell_wave_height": [null, null, null],
+			"swell_wave_period": [9, 10]
+		}
+	}`
+	var raw openMeteoMarineResp
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatal(err)
+	}
+	r, err := raw.rows(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"2026-03-08T01:00:00-08:00", "2026-03-08T03:00:00-07:00", "2026-10-17T05:00:00-07:00"}
+	if got := hourTimes(r.Hours); !slices.Equal(got, expected) {
+		t.Fatalf("Returned times did not match expected times:\n\tReturned: %v\n\tExpected: %v", got, expected)
+	}
+	if r.Timezone != "America/Los_Angeles" {
+		t.Fatalf("expected timezone America/Los_Angeles, got %s", r.Timezone)
+	}
+	if r.Hours[0].WaveHeight == nil || *r.Hours[0].WaveHeight != 2.1 || r.Hours[1].WaveHeight != nil {
+		t.Fatalf("expected null wave heights to be unset, got %+v", r.Hours)
+	}
+
+	// Variables absent from the response are missing every hour.
+	missing := make(map[string]int)
+	for _, c := range marineColumns {
+		missing[c.name] = 3
+	}
+	missing["wave_height"], missing["swell_wave_period"] = 1, 1
+	if !maps.Equal(r.Missing, missing) {
+		t.Fatalf("Returned missing counts did not match expected counts:\n\tReturned: %v\n\tExpected: %v", r.Missing, missing)
+	}
+}
+
+func testHours(t *testing.T, hours int) MarineHours {
+	t.Helper()
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 10, 17, 0, 0, 0, 0, loc)
+	hs := make(MarineHours, 0, hours)
+	for i := range hours {
+		hs = append(hs, MarineHour{
+			Time:               start.Add(time.Duration(i) * time.Hour),
+			WaveHeight:         ptr(float64(i % 24)),
+			SwellWaveDirection: ptr(float64((350 + 20*(i%2)) % 360)),
+		})
+	}
+	return hs
+}
+
+func TestMarineHoursWindow(t *testing.T) {
+	hs := testHours(t, 48)
+	loc, _ := time.LoadLocation("America/Chicago")
+
+	testCases := []struct {
+		name     string
+		start    string
+		end      string
+		step     int
+		expected []string
+	}{
+		{
+			name:     "local hours with step",
+			start:    "2026-10-17T06:00",
+			end:      "2026-10-17T12:00",
+			step:     3,
+			expected: []string{"2026-10-17T06:00:00-05:00", "2026-10-17T09:00:00-05:00", "2026-10-17T12:00:00-05:00"},
+		},
+		{
+			name:     "bare end date includes the whole day",
+			start:    "2026-10-18T22:00:00-05:00",
+			end:      "2026-10-18",
+			step:     1,
+			expected: []string{"2026-10-18T22:00:00-05:00", "2026-10-18T23:00:00-05:00"},
+		},
+		{
+			name:     "RFC3339 in another zone",
+			start:    "2026-10-17T05:00:00Z",
+			end:      "2026-10-17T06:00:00Z",
+			step:     1,
+			expected: []string{"2026-10-17T00:00:00-05:00", "2026-10-17T01:00:00-05:00"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			start, err := parseWindowTime(tt.start, loc, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end, err := parseWindowTime(tt.end, loc, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := hourTimes(hs.Between(start, end).Every(tt.step))
+			if !slices.Equal(got, tt.expected) {
+				t.Fatalf("Returned times did not match expected times:\n\tReturned: %v\n\tExpected: %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarineHoursEverySkipsGaps(t *testing.T) {
+	hs := testHours(t, 12)
+	hs = slices.Delete(hs, 3, 4)
+
+	expected := []string{"2026-10-17T00:00:00-05:00", "2026-10-17T04:00:00-05:00", "2026-10-17T06:00:00-05:00", "2026-10-17T09:00:00-05:00"}
+	if got := hourTimes(hs.Every(3)); !slices.Equal(got, expected) {
+		t.Fatalf("Returned times did not match expected times:\n\tReturned: %v\n\tExpected: %v", got, expected)
+	}
+}
+
+func TestMarineHoursAt(t *testing.T) {
+	hs := testHours(t, 24)
+	h, ok := hs.At(hs[5].Time.Add(30 * time.Minute))
+	if !ok || !h.Time.Equal(hs[5].Time) {
+		t.Fatalf("expected hour %v, got %v (found %t)", hs[5].Time, h.Time, ok)
+	}
+	if _, ok := hs.At(hs[23].Time.Add(time.Hour)); ok {
+		t.Fatal("expected no hour past the end of the series")
+	}
+}
+
+func TestMarineHoursDaily(t *testing.T) {
+	days := testHours(t, 48).daily()
+	if len(days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(days))
+	}
+
+	d := days[0]
+	expected := Stat{Min: 0, Max: 23, Mean: 11.5}
+	if d.Date != "2026-10-17" || d.Hours != 24 || d.WaveHeight == nil || *d.WaveHeight != expected {
+		t.Fatalf("Returned day did not match expected day:\n\tReturned: %+v\n\tExpected: wave height %+v", d, expected)
+	}
+	if d.SwellWaveDirection == nil || *d.SwellWaveDirection != 0 {
+		t.Fatalf("expected circular mean swell direction 0, got %v", deref(d.SwellWaveDirection))
+	}
+	if d.WavePeriod != nil {
+		t.Fatalf("expected missing wave period to be omitted, got %+v", d.WavePeriod)
+	}
+
+	noDir := MarineHours{{Time: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), WaveHeight: ptr(1)}}.daily()
+	if noDir[0].SwellWaveDirection != nil {
+		t.Fatalf("expected missing swell direction to be omitted, got %v", *noDir[0].SwellWaveDirection)
+	}
+}
+
+func TestParseWindowTimeInvalid(t *testing.T) {
+	if _, err := parseWindowTime("tomorrow", time.UTC, false); !errors.Is(err, ErrInvalidForecastWindow) {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrInvalidForecastWindow)
+	}
+}
+
+func TestDominantSwell(t *testing.T) {
+	testCases := []struct {
+		hour     MarineHour
+		expected string
+	}{
+		{MarineHour{SwellWaveHeight: ptr(3), SwellWavePeriod: ptr(12), SecondarySwellWaveHeight: ptr(2), SecondarySwellWavePeriod: ptr(14)}, "swell"},
+		{MarineHour{SwellWaveHeight: ptr(2), SwellWavePeriod: ptr(8), SecondarySwellWaveHeight: ptr(2), SecondarySwellWavePeriod: ptr(14)}, "secondary_swell"},
+		{MarineHour{SwellWaveHeight: ptr(1), SwellWavePeriod: ptr(6), TertiarySwellWaveHeight: ptr(2), TertiarySwellWavePeriod: ptr(10)}, "tertiary_swell"},
+		{MarineHour{SwellWaveHeight: ptr(0.5), SwellWavePeriod: ptr(5), WindWaveHeight: ptr(2), WindWavePeriod: ptr(5)}, "wind_wave"},
+		{MarineHour{SwellWaveHeight: ptr(4)}, "none"},
+	}
+
+	for _, tt := range testCases {
+		if got := tt.hour.dominantSwell(); got != tt.expected {
+			t.Fatalf("Returned partition did not match expected partition:\n\tReturned: %v\n\tExpected: %v", got, tt.expected)
+		}
+	}
+}
+
+// fakeMarine serves land cells, with all-null waves, west of landLon and
+// water cells east of it.
+func fakeMarine(t *testing.T, landLon float64) *atomic.Int32 {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var lat, lon float64
+		fmt.Sscan(r.URL.Query().Get("latitude"), &lat)
+		fmt.Sscan(r.URL.Query().Get("longitude"), &lon)
+
+		height := "null"
+		if lon > landLon {
+			height = "3.2"
+		}
+		fmt.Fprintf(w, `{"latitude": %.3f, "longitude": %.3f, "hourly": {"time": ["2026-10-17T05:00"], "wave_height": [%s]}}`, lat, lon, height)
+	}))
+	t.Cleanup(srv.Close)
+
+	orig := marineBaseUrl
+	marineBaseUrl = srv.URL
+	t.Cleanup(func() { marineBaseUrl = orig })
+	return &requests
+}
+
+func TestFetchMarineForecastSeawardOffset(t *testing.T) {
+	s := &spot.Spot{Name: "Empire Beach", State: "Michigan", Latitude: 44.81, Longitude: -86.06, Facing: spot.Direction(270)}
+
+	// Water lies behind the spot, so every step along its facing is land.
+	fakeMarine(t, -86.0)
+	if _, err := fetchMarineForecast(s, 0, 0); !errors.Is(err, ErrNoMarineData) {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrNoMarineData)
+	}
+
+	// Water begins about 2.5 miles along the spot's facing.
+	requests := fakeMarine(t, -86.2)
+	s.Facing = spot.Direction(90)
+	s.Longitude = -86.25
+	resp, err := fetchMarineForecast(s, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.OffshoreMiles != 5 || requests.Load() != 3 {
+		t.Fatalf("expected the 5 mile offset on the third request, got %v miles after %d requests", resp.OffshoreMiles, requests.Load())
+	}
+	if resp.Longitude <= -86.2 || resp.Hours[0].WaveHeight == nil {
+		t.Fatalf("expected the water cell's coordinate and waves, got %+v", resp)
+	}
+}
+
+func TestResolveCurrents(t *testing.T) {
+	// Ocean Beach faces west, so a northward current runs along the beach to
+	// the right looking out to sea and a westward one runs straight offshore.
+	hs := MarineHours{
+		{OceanCurrentVelocity: ptr(1), OceanCurrentDirection: ptr(0)},
+		{OceanCurrentVelocity: ptr(2), OceanCurrentDirection: ptr(270)},
+		{OceanCurrentVelocity: ptr(1), OceanCurrentDirection: ptr(135)},
+		{OceanCurrentVelocity: ptr(1)},
+	}
+	hs.resolveCurrents(spot.Direction(270))
+
+	expected := [][2]float64{{1, 0}, {0, 2}, {-0.71, -0.71}}
+	for i, e := range expected {
+		got := [2]float64{*hs[i].LongshoreCurrent, *hs[i].CrossShoreCurrent}
+		if got != e {
+			t.Fatalf("Returned components did not match expected components for hour %d:\n\tReturned: %v\n\tExpected: %v", i, got, e)
+		}
+	}
+	if hs[3].LongshoreCurrent != nil {
+		t.Fatalf("expected no components without a current direction, got %v", *hs[3].LongshoreCurrent)
+	}
+}
@@ -0,0 +1,279 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	maxMarineForecastDays = 16
+	maxMarinePastDays     = 92
+)
+
+var ErrInvalidForecastWindow = errors.New("invalid forecast window")
+
+type MarineForecastArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	Start        string `json:"start,omitempty" jsonschema_description:"First hour to return, as RFC3339 or as 'YYYY-MM-DD' or 'YYYY-MM-DDTHH:MM' in the spot's timezone. Defaults to the first available hour."`
+	End          string `json:"end,omitempty" jsonschema_description:"Last hour to return (inclusive), in the same formats as start. A bare date includes that whole day. Defaults to the last available hour."`
+	ForecastDays int    `json:"forecast_days,omitempty" jsonschema_description:"Days of forecast to fetch, 1-16. Defaults to Open-Meteo's default of 5."`
+	PastDays     int    `json:"past_days,omitempty" jsonschema_description:"Days of past model data to include before today, 0-92."`
+	StepHours    int    `json:"step_hours,omitempty" jsonschema_description:"Return every Nth hour of the window, e.g. 3 for 3-hourly. Defaults to 1."`
+	Daily        bool   `json:"daily,omitempty" jsonschema_description:"Return a compact min/max/mean summary per local day instead of hourly values."`
+}
+
+// MarineForecastResp is the requested slice of the marine forecast, either as
+// hourly records or as a per-day summary.
+type MarineForecastResp struct {
+	Latitude      float64        `json:"latitude" jsonschema_description:"Latitude of the marine grid cell the forecast is for."`
+	Longitude     float64        `json:"longitude" jsonschema_description:"Longitude of the marine grid cell the forecast is for."`
+	OffshoreMiles float64        `json:"offshore_miles" jsonschema_description:"How far seaward of the spot, along its facing direction, the forecast was requested because the spot's own grid cell is land. 0 when the spot's cell has wave data."`
+	Timezone      string         `json:"timezone" jsonschema_description:"IANA timezone of the returned times, which are RFC3339 with the UTC offset included."`
+	HourlyUnits   HourlyUnits    `json:"hourly_units"`
+	Hours         MarineHours    `json:"hours,omitempty" jsonschema_description:"One record per returned hour, in time order. Omitted in daily mode."`
+	Daily         []MarineDay    `json:"daily,omitempty" jsonschema_description:"Per-day summary of the requested window. Only set in daily mode."`
+	Missing       map[string]int `json:"missing" jsonschema_description:"Number of hours in the window each variable has no value. Only variables with gaps are listed."`
+}
+
+// Stat summarizes one variable over a day.
+type Stat struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// MarineDay is the marine forecast for one local day, in hourly_units.
+// Variables with no values that day are omitted.
+type MarineDay struct {
+	Date               string   `json:"date" jsonschema_description:"Local date as YYYY-MM-DD."`
+	Hours              int      `json:"hours" jsonschema_description:"Number of hours the day's summary covers."`
+	WaveHeight         *Stat    `json:"wave_height,omitempty"`
+	WavePeriod         *Stat    `json:"wave_period,omitempty"`
+	SwellWaveHeight    *Stat    `json:"swell_wave_height,omitempty"`
+	SwellWavePeriod    *Stat    `json:"swell_wave_period,omitempty"`
+	SwellWaveDirection *float64 `json:"swell_wave_direction,omitempty" jsonschema_description:"Circular mean swell direction in degrees true."`
+	WindWaveHeight     *Stat    `json:"wind_wave_height,omitempty"`
+	SeaLevelHeightMsl  *Stat    `json:"sea_level_height_msl,omitempty"`
+
+	SeaSurfaceTemperature *Stat `json:"sea_surface_temperature,omitempty"`
+	OceanCurrentVelocity  *Stat `json:"ocean_current_velocity,omitempty"`
+	LongshoreCurrent      *Stat `json:"longshore_current,omitempty"`
+}
+
+// GetMarineForecast fetches the hourly marine forecast and returns only the
+// requested window, optionally thinned to every StepHours hour or collapsed
+// into a daily summary.
+func GetMarineForecast(ctx tool.Context, a *MarineForecastArgs) (*MarineForecastResp, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	loc := a.Spot.Location()
+	start, err := parseWindowTime(a.Start, loc, false)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseWindowTime(a.End, loc, true)
+	if err != nil {
+		return nil, err
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, fmt.Errorf("end %s is before start %s: %w", a.End, a.Start, ErrInvalidForecastWindow)
+	}
+
+	forecast, err := fetchMarineForecast(a.Spot, a.ForecastDays, a.PastDays)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := forecast.Hours.Between(start, end)
+	resp := &MarineForecastResp{
+		Latitude:      forecast.Latitude,
+		Longitude:     forecast.Longitude,
+		OffshoreMiles: forecast.OffshoreMiles,
+		Timezone:      forecast.Timezone,
+		HourlyUnits:   forecast.HourlyUnits,
+		Missing:       hours.Missing(),
+	}
+	if a.Daily {
+		resp.Daily = hours.daily()
+	} else {
+		resp.Hours = hours.Every(max(a.StepHours, 1))
+	}
+	return resp, nil
+}
+
+func (a *MarineForecastArgs) validate() error {
+	switch {
+	case a.Spot == nil:
+		return fmt.Errorf("spot is required: %w", ErrInvalidArgs)
+	case a.ForecastDays < 0 || a.ForecastDays > maxMarineForecastDays:
+		return fmt.Errorf("forecast_days must be between 1 and %d: %w", maxMarineForecastDays, ErrInvalidForecastWindow)
+	case a.PastDays < 0 || a.PastDays > maxMarinePastDays:
+		return fmt.Errorf("past_days must be between 0 and %d: %w", maxMarinePastDays, ErrInvalidForecastWindow)
+	case a.StepHours < 0:
+		return fmt.Errorf("step_hours must be positive: %w", ErrInvalidForecastWindow)
+	}
+	return nil
+}
+
+// parseWindowTime parses a window bound. Bare dates start at midnight, or for
+// an end bound include the whole day. An empty bound is the zero time.
+func parseWindowTime(v string, loc *time.Location, end bool) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(openMeteoTimeFormat, v, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, v, loc); err == nil {
+		if end {
+			return t.AddDate(0, 0, 1).Add(-time.Minute), nil
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("can't parse %q as RFC3339, YYYY-MM-DD or YYYY-MM-DDTHH:MM: %w", v, ErrInvalidForecastWindow)
+}
+
+// MarineHours is a time-ordered run of hourly marine forecast records.
+type MarineHours []MarineHour
+
+// Between returns the hours from start to end inclusive. Zero bounds are open.
+func (hs MarineHours) Between(start, end time.Time) MarineHours {
+	out := make(MarineHours, 0, len(hs))
+	for _, h := range hs {
+		if inWindow(h.Time, start, end) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// inWindow reports whether t falls between start and end inclusive. Zero
+// bounds are open.
+func inWindow(t, start, end time.Time) bool {
+	return (start.IsZero() || !t.Before(start)) && (end.IsZero() || !t.After(end))
+}
+
+// Every returns every step-th hour counting from the first, skipping over gaps
+// in the series by time rather than by index.
+func (hs MarineHours) Every(step int) MarineHours {
+	return everyHour(hs, step, func(h MarineHour) time.Time { return h.Time })
+}
+
+// everyHour thins any hourly series the way MarineHours.Every does, reading
+// each hour's start time with at.
+func everyHour[T any](hs []T, step int, at func(T) time.Time) []T {
+	out := make([]T, 0, len(hs))
+	for _, h := range hs {
+		if len(out) > 0 && at(h).Sub(at(out[0])) < time.Duration(step*len(out))*time.Hour {
+			continue
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// At returns the hour containing t.
+func (hs MarineHours) At(t time.Time) (MarineHour, bool) {
+	for _, h := range hs {
+		if !t.Before(h.Time) && t.Before(h.Time.Add(time.Hour)) {
+			return h, true
+		}
+	}
+	return MarineHour{}, false
+}
+
+// Days splits the hours by local date.
+func (hs MarineHours) Days() []MarineHours {
+	var days []MarineHours
+	for i, h := range hs {
+		if i == 0 || h.Time.Format(time.DateOnly) != hs[i-1].Time.Format(time.DateOnly) {
+			days = append(days, MarineHours{})
+		}
+		days[len(days)-1] = append(days[len(days)-1], h)
+	}
+	return days
+}
+
+// Missing counts the hours each variable has no value, keyed by Open-Meteo
+// variable name. Variables without gaps are left out.
+func (hs MarineHours) Missing() map[string]int {
+	missing := make(map[string]int)
+	for i := range hs {
+		for _, c := range marineColumns {
+			if *c.field(&hs[i]) == nil {
+				missing[c.name]++
+			}
+		}
+	}
+	return missing
+}
+
+// daily summarizes the hours by local date.
+func (hs MarineHours) daily() []MarineDay {
+	days := make([]MarineDay, 0)
+	for _, day := range hs.Days() {
+		var dir weightedDirection
+		for _, h := range day {
+			if h.SwellWaveDirection != nil {
+				dir.add(*h.SwellWaveDirection, 1)
+			}
+		}
+		var swellDir *float64
+		if d := dir.value(); d >= 0 {
+			swellDir = &d
+		}
+		days = append(days, MarineDay{
+			Date:               day[0].Time.Format(time.DateOnly),
+			Hours:              len(day),
+			WaveHeight:         day.stat(func(h *MarineHour) *float64 { return h.WaveHeight }),
+			WavePeriod:         day.stat(func(h *MarineHour) *float64 { return h.WavePeriod }),
+			SwellWaveHeight:    day.stat(func(h *MarineHour) *float64 { return h.SwellWaveHeight }),
+			SwellWavePeriod:    day.stat(func(h *MarineHour) *float64 { return h.SwellWavePeriod }),
+			SwellWaveDirection: swellDir,
+			WindWaveHeight:     day.stat(func(h *MarineHour) *float64 { return h.WindWaveHeight }),
+			SeaLevelHeightMsl:  day.stat(func(h *MarineHour) *float64 { return h.SeaLevelHeightMsl }),
+
+			SeaSurfaceTemperature: day.stat(func(h *MarineHour) *float64 { return h.SeaSurfaceTemperature }),
+			OceanCurrentVelocity:  day.stat(func(h *MarineHour) *float64 { return h.OceanCurrentVelocity }),
+			LongshoreCurrent:      day.stat(func(h *MarineHour) *float64 { return h.LongshoreCurrent }),
+		})
+	}
+	return days
+}
+
+// stat returns the min, max and mean of a variable over the hours, or nil when
+// it has no values.
+func (hs MarineHours) stat(field func(*MarineHour) *float64) *Stat {
+	var min, max, sum float64
+	n := 0
+	for i := range hs {
+		v := field(&hs[i])
+		if v == nil {
+			continue
+		}
+		if n == 0 || *v < min {
+			min = *v
+		}
+		if n == 0 || *v > max {
+			max = *v
+		}
+		sum += *v
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	return &Stat{Min: round(min), Max: round(max), Mean: round(sum / float64(n))}
+}
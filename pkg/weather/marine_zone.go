@@ -0,0 +1,366 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+// marineZoneProducts are the NWS text products searched for a zone's
+// forecast, in order: the nearshore marine forecast (Great Lakes and some
+// coasts) and the coastal waters forecast.
+var marineZoneProducts = []string{"NSH", "CWF"}
+
+var ErrNoMarineZone = errors.New("no NWS marine zone forecast for the spot")
+
+// compassWords maps the spelled-out directions NWS uses in marine text to
+// compass abbreviations.
+var compassWords = map[string]string{
+	"north": "N", "northeast": "NE", "east": "E", "southeast": "SE",
+	"south": "S", "southwest": "SW", "west": "W", "northwest": "NW",
+}
+
+var (
+	marinePeriodRe   = regexp.MustCompile(`^\.([A-Z][A-Z0-9 ]*?)\.\.\.(.*)$`)
+	marineHeadlineRe = regexp.MustCompile(`\.\.\.(.+?)\.\.\.`)
+	marineSentenceRe = regexp.MustCompile(`\.\s+`)
+	marineWindRe     = regexp.MustCompile(`(?i)\b(?:([a-z]+)\s+)?winds?\b(\s+waves)?`)
+	marineWaveRe     = regexp.MustCompile(`(?i)(\d+)(?:\s+to\s+(\d+))?\s*(?:feet|foot|ft)\b(\s+or\s+less)?`)
+	ugcExpiryRe      = regexp.MustCompile(`^\d{6}$`)
+	ugcZoneRe        = regexp.MustCompile(`^([A-Z]{3})?(\d{3})(?:>(\d{3}))?$`)
+)
+
+type MarineZoneForecast struct {
+	Zone      string             `json:"zone" jsonschema_description:"NWS marine zone ID, e.g. 'LSZ145'. On the Great Lakes, zones numbered below 200 are nearshore (within about 5 miles of shore)."`
+	Name      string             `json:"name" jsonschema_description:"Zone name as written in the forecast, e.g. 'Duluth MN to Port Wing WI'."`
+	Product   string             `json:"product" jsonschema_description:"NWS text product the forecast came from: 'NSH' nearshore marine forecast or 'CWF' coastal waters forecast."`
+	Office    string             `json:"office" jsonschema_description:"NWS office that issued the forecast."`
+	Issued    string             `json:"issued" jsonschema_description:"RFC3339 time the forecast was issued, in the spot's timezone."`
+	Headlines []string           `json:"headlines" jsonschema_description:"Active headlines such as 'SMALL CRAFT ADVISORY IN EFFECT UNTIL 4 PM CDT THIS AFTERNOON'. Empty when none are in effect."`
+	Periods   []MarineZonePeriod `json:"periods"`
+}
+
+type MarineZonePeriod struct {
+	Name             string   `json:"name" jsonschema_description:"Period name, e.g. 'TONIGHT' or 'FRIDAY NIGHT'."`
+	Forecast         string   `json:"forecast" jsonschema_description:"Full forecast text for the period."`
+	WindDirection    string   `json:"wind_direction,omitempty" jsonschema_description:"Compass direction of the first wind mentioned, where the wind blows from."`
+	WindDirectionDeg *float64 `json:"wind_direction_deg,omitempty"`
+	WindMinKt        *float64 `json:"wind_min_kt,omitempty" jsonschema_description:"Lowest wind speed mentioned for the period, in knots."`
+	WindMaxKt        *float64 `json:"wind_max_kt,omitempty" jsonschema_description:"Highest wind speed mentioned for the period, in knots."`
+	WindGustKt       *float64 `json:"wind_gust_kt,omitempty" jsonschema_description:"Gust speed in knots, when stated."`
+	WaveMinFt        *float64 `json:"wave_min_ft,omitempty" jsonschema_description:"Lower bound of the waves or seas in feet; 0 for 'N feet or less'."`
+	WaveMaxFt        *float64 `json:"wave_max_ft,omitempty" jsonschema_description:"Upper bound of the waves or seas in feet."`
+}
+
+type marineZoneResp struct {
+	Properties struct {
+		Name string   `json:"name"`
+		Cwa  []string `json:"cwa"`
+	} `json:"properties"`
+}
+
+type nwsProductList struct {
+	Graph []struct {
+		ID string `json:"id"`
+	} `json:"@graph"`
+}
+
+type nwsProduct struct {
+	IssuanceTime  string `json:"issuanceTime"`
+	IssuingOffice string `json:"issuingOffice"`
+	ProductText   string `json:"productText"`
+}
+
+// GetMarineZoneForecast resolves the spot's NWS marine zone and returns the
+// zone's section of the latest nearshore or coastal waters forecast, parsed
+// into headlines and per-period wind and wave fields. This is the
+// authoritative forecast for Great Lakes spots, where the NWS gridpoint
+// forecast is often unavailable.
+// https://www.weather.gov/marine/
+func GetMarineZoneForecast(ctx tool.Context, s *spot.Spot) (*MarineZoneForecast, error) {
+	return defaultGridPoints().marineZoneForecast(ctx, s)
+}
+
+func (c *GridPointCache) marineZoneForecast(ctx context.Context, s *spot.Spot) (*MarineZoneForecast, error) {
+	gp, err := c.Get(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if gp.MarineZone == "" {
+		return nil, fmt.Errorf("%s: %w", s.Name, ErrNoMarineZone)
+	}
+	zone := path.Base(gp.MarineZone)
+
+	// The marine forecast can come from a different office than the
+	// gridpoint, so prefer the zone's own office.
+	offices := []string{gp.Office}
+	var zr marineZoneResp
+	if err := c.getJSON(ctx, "zones/marine/"+zone, &zr); err == nil && len(zr.Properties.Cwa) > 0 {
+		offices = append(zr.Properties.Cwa[:1:1], gp.Office)
+	}
+
+	for _, office := range offices {
+		for _, product := range marineZoneProducts {
+			p, err := c.latestProduct(ctx, product, office)
+			if err != nil {
+				continue
+			}
+
+			f, ok := parseMarineZoneProduct(p.ProductText, zone)
+			if !ok {
+				continue
+			}
+			f.Zone = zone
+			f.Product = product
+			f.Office = office
+			f.Issued = localizeTimestamp(s, p.IssuanceTime)
+			if f.Name == "" {
+				f.Name = zr.Properties.Name
+			}
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("%s zone %s: %w", s.Name, zone, ErrNoMarineZone)
+}
+
+// latestProduct returns the office's most recent issuance of a text product
+// such as "NSH" or "SRF".
+func (c *GridPointCache) latestProduct(ctx context.Context, product, office string) (*nwsProduct, error) {
+	var list nwsProductList
+	if err := c.getJSON(ctx, fmt.Sprintf("products/types/%s/locations/%s", product, office), &list); err != nil {
+		return nil, err
+	}
+	if len(list.Graph) == 0 {
+		return nil, fmt.Errorf("no %s product from %s: %w", product, office, ErrInvalidHttpResponse)
+	}
+	var p nwsProduct
+	if err := c.getJSON(ctx, "products/"+path.Base(list.Graph[0].ID), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// parseMarineZoneProduct finds the segment of a marine text product covering
+// zone and parses it.
+func parseMarineZoneProduct(text, zone string) (*MarineZoneForecast, bool) {
+	lines, ok := productSegment(text, zone)
+	if !ok {
+		return nil, false
+	}
+	return parseMarineSegment(lines), true
+}
+
+// productSegment returns the lines after the UGC line of the text product
+// segment covering zone. Segments are separated by "$$" and start with a UGC
+// line listing their zones, e.g. "LSZ144>146-162115-".
+func productSegment(text, zone string) ([]string, bool) {
+	text = strings.ReplaceAll(text, "\r", "")
+	for _, segment := range strings.Split(text, "$$") {
+		lines := strings.Split(strings.TrimSpace(segment), "\n")
+
+		// The UGC line is the first one ending in an expiry time; it may
+		// wrap onto several lines.
+		ugc, rest := "", lines
+		for i, l := range lines {
+			l = strings.TrimSpace(l)
+			if !strings.HasSuffix(l, "-") || !strings.ContainsAny(l, "0123456789") {
+				ugc = ""
+				continue
+			}
+			ugc += l
+			parts := strings.Split(strings.TrimSuffix(l, "-"), "-")
+			if ugcExpiryRe.MatchString(parts[len(parts)-1]) {
+				rest = lines[i+1:]
+				break
+			}
+		}
+		if ugc == "" || !containsZone(ugcZones(ugc), zone) {
+			continue
+		}
+		return rest, true
+	}
+	return nil, false
+}
+
+// ugcZones expands a UGC line such as "LSZ144>146-150-162115-" into its zone
+// IDs.
+func ugcZones(ugc string) []string {
+	zones := make([]string, 0)
+	prefix := ""
+	for _, tok := range strings.Split(ugc, "-") {
+		m := ugcZoneRe.FindStringSubmatch(strings.TrimSpace(tok))
+		if m == nil {
+			continue
+		}
+		if m[1] != "" {
+			prefix = m[1]
+		}
+		if prefix == "" {
+			continue
+		}
+		last := m[2]
+		if m[3] != "" {
+			last = m[3]
+		}
+		from, _ := strconv.Atoi(m[2])
+		to, _ := strconv.Atoi(last)
+		for n := from; n <= to; n++ {
+			zones = append(zones, fmt.Sprintf("%s%03d", prefix, n))
+		}
+	}
+	return zones
+}
+
+func containsZone(zones []string, zone string) bool {
+	for _, z := range zones {
+		if strings.EqualFold(z, zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentHeader splits the lines after a segment's UGC line into the zone
+// names, which end in "-", the header lines up to the first ".PERIOD..." line
+// and the remaining trimmed lines.
+func segmentHeader(lines []string) (name string, header, rest []string) {
+	i := 0
+	names := make([]string, 0)
+	for ; i < len(lines) && strings.HasSuffix(strings.TrimSpace(lines[i]), "-"); i++ {
+		names = append(names, strings.TrimSuffix(strings.TrimSpace(lines[i]), "-"))
+	}
+	for ; i < len(lines); i++ {
+		l := strings.TrimSpace(lines[i])
+		if marinePeriodRe.MatchString(l) {
+			rest = append(rest, l)
+			continue
+		}
+		if len(rest) > 0 {
+			rest = append(rest, l)
+			continue
+		}
+		header = append(header, l)
+	}
+	return strings.Join(names, "; "), header, rest
+}
+
+// segmentHeadlines returns the "...HEADLINE..." text in a segment's header.
+// Mixed-case "..." runs, such as lists of included beaches, are skipped.
+func segmentHeadlines(header []string) []string {
+	headlines := make([]string, 0)
+	for _, m := range marineHeadlineRe.FindAllStringSubmatch(strings.Join(header, " "), -1) {
+		h := strings.Join(strings.Fields(m[1]), " ")
+		if h != "" && h == strings.ToUpper(h) {
+			headlines = append(headlines, h)
+		}
+	}
+	return headlines
+}
+
+// parseMarineSegment parses the lines after a segment's UGC line: the zone
+// names ending in "-", the issuance time, any "...HEADLINE..." lines and the
+// ".PERIOD..." forecasts.
+func parseMarineSegment(lines []string) *MarineZoneForecast {
+	f := &MarineZoneForecast{
+		Headlines: make([]string, 0),
+		Periods:   make([]MarineZonePeriod, 0),
+	}
+
+	var header []string
+	f.Name, header, lines = segmentHeader(lines)
+	f.Headlines = segmentHeadlines(header)
+
+	for _, l := range lines {
+		if m := marinePeriodRe.FindStringSubmatch(l); m != nil {
+			f.Periods = append(f.Periods, MarineZonePeriod{Name: m[1], Forecast: m[2]})
+			continue
+		}
+		if n := len(f.Periods); n > 0 {
+			f.Periods[n-1].Forecast += " " + l
+		}
+	}
+	for i := range f.Periods {
+		p := &f.Periods[i]
+		p.Forecast = strings.Join(strings.Fields(p.Forecast), " ")
+		p.parse()
+	}
+	return f
+}
+
+// parse fills in the period's wind and wave fields from the first sentence
+// mentioning wind and the sentence describing the waves or seas.
+func (p *MarineZonePeriod) parse() {
+	sentences := marineSentenceRe.Split(p.Forecast, -1)
+
+	for _, s := range sentences {
+		m := marineWindRe.FindStringSubmatchIndex(s)
+		if m == nil || m[4] >= 0 {
+			// No wind, or only the wind waves.
+			continue
+		}
+		if m[2] >= 0 {
+			word := strings.ToLower(s[m[2]:m[3]])
+			if abbr, ok := compassWords[word]; ok {
+				word = abbr
+			}
+			if d, err := spot.ParseDirection(word); err == nil {
+				p.WindDirection = d.Compass()
+				deg := d.Degrees()
+				p.WindDirectionDeg = &deg
+			}
+		}
+
+		lo, hi, gust := parseNwsWindSpeed(s[m[1]:])
+		p.WindMinKt, p.WindMaxKt, p.WindGustKt = toKnots(lo), toKnots(hi), toKnots(gust)
+		break
+	}
+
+	for _, key := range []string{"combined seas", "seas", "waves"} {
+		for _, s := range sentences {
+			lower := strings.ToLower(s)
+			i := strings.Index(lower, key)
+			if i < 0 || key == "waves" && strings.Contains(lower, "wind waves") {
+				continue
+			}
+			if lo, hi := parseFeetRange(s[i:]); lo != nil {
+				p.WaveMinFt, p.WaveMaxFt = lo, hi
+				return
+			}
+		}
+	}
+}
+
+// parseFeetRange parses the first height in feet in s, such as "3 to 5 feet",
+// "4 ft" or "2 feet or less", as its low and high bounds. "Or less" bounds
+// start at zero. Both are nil when s gives no height.
+func parseFeetRange(s string) (lo, hi *float64) {
+	m := marineWaveRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, nil
+	}
+	l, _ := strconv.ParseFloat(m[1], 64)
+	h := l
+	if m[2] != "" {
+		h, _ = strconv.ParseFloat(m[2], 64)
+	}
+	if m[3] != "" {
+		l = 0
+	}
+	return &l, &h
+}
+
+func toKnots(mph *float64) *float64 {
+	if mph == nil {
+		return nil
+	}
+	kt := math.Round(*mph/mphPerUnit[WindUnitKnots]*10) / 10
+	return &kt
+}
@@ -0,0 +1,151 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+const testNshProduct = `000
+FZUS53 KDLH 161530
+NSHDLH
+
+Nearshore Marine Forecast
+National Weather Service Duluth MN
+1030 AM CDT Thu Oct 16 2026
+
+LSZ140>143-170415-
+Port Wing to Sand Island WI-
+1030 AM CDT Thu Oct 16 2026
+
+.THIS AFTERNOON...West wind 5 to 10 knots. Waves 1 foot or less.
+$$
+
+LSZ144>146-170415-
+Two Harbors to Duluth MN-
+1030 AM CDT Thu Oct 16 2026
+
+...SMALL CRAFT ADVISORY IN EFFECT UNTIL 4 PM CDT THIS
+AFTERNOON...
+
+.THIS AFTERNOON...Northeast wind 15 to 25 knots with gusts up to
+30 knots. Waves 4 to 7 feet.
+.TONIGHT...North winds 10 to 20 knots becoming northwest after
+2 AM. Waves 3 to 5 feet subsiding to 2 to 4 feet after midnight.
+.FRIDAY...Light and variable winds. Waves 2 feet or less.
+$$
+`
+
+func TestParseMarineZoneProduct(t *testing.T) {
+	f, ok := parseMarineZoneProduct(testNshProduct, "LSZ145")
+	if !ok {
+		t.Fatal("expected LSZ145 to be found in LSZ144>146")
+	}
+	if f.Name != "Two Harbors to Duluth MN" {
+		t.Fatalf("Returned name did not match expected name:\n\tReturned: %q\n\tExpected: %q", f.Name, "Two Harbors to Duluth MN")
+	}
+	if want := []string{"SMALL CRAFT ADVISORY IN EFFECT UNTIL 4 PM CDT THIS AFTERNOON"}; !slices.Equal(f.Headlines, want) {
+		t.Fatalf("Returned headlines did not match expected headlines:\n\tReturned: %q\n\tExpected: %q", f.Headlines, want)
+	}
+	if len(f.Periods) != 3 {
+		t.Fatalf("expected 3 periods, got %d: %+v", len(f.Periods), f.Periods)
+	}
+
+	testCases := []struct {
+		name                       string
+		direction                  string
+		windMin, windMax, windGust float64
+		waveMin, waveMax           float64
+	}{
+		{name: "THIS AFTERNOON", direction: "NE", windMin: 15, windMax: 25, windGust: 30, waveMin: 4, waveMax: 7},
+		{name: "TONIGHT", direction: "N", windMin: 10, windMax: 20, windGust: -1, waveMin: 3, waveMax: 5},
+		{name: "FRIDAY", direction: "", windMin: -1, windMax: -1, windGust: -1, waveMin: 0, waveMax: 2},
+	}
+	for i, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := f.Periods[i]
+			if p.Name != tt.name || p.WindDirection != tt.direction {
+				t.Fatalf("Returned period did not match expected period:\n\tReturned: %s %q\n\tExpected: %s %q", p.Name, p.WindDirection, tt.name, tt.direction)
+			}
+			got := []float64{deref(p.WindMinKt), deref(p.WindMaxKt), deref(p.WindGustKt), deref(p.WaveMinFt), deref(p.WaveMaxFt)}
+			want := []float64{tt.windMin, tt.windMax, tt.windGust, tt.waveMin, tt.waveMax}
+			if !slices.Equal(got, want) {
+				t.Fatalf("Returned wind and waves did not match expected wind and waves:\n\tReturned: %v\n\tExpected: %v\n\tText: %s", got, want, p.Forecast)
+			}
+		})
+	}
+
+	if _, ok := parseMarineZoneProduct(testNshProduct, "LSZ162"); ok {
+		t.Fatal("expected LSZ162 not to be found")
+	}
+}
+
+func TestUgcZones(t *testing.T) {
+	got := ugcZones("LMZ344>346-348-LSZ162-CAZ008>010-170415-")
+	want := []string{"LMZ344", "LMZ345", "LMZ346", "LMZ348", "LSZ162", "CAZ008", "CAZ009", "CAZ010"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Returned zones did not match expected zones:\n\tReturned: %v\n\tExpected: %v", got, want)
+	}
+}
+
+func TestParseFeetRange(t *testing.T) {
+	testCases := []struct {
+		in     string
+		lo, hi float64
+	}{
+		{in: "Waves 3 to 5 feet.", lo: 3, hi: 5},
+		{in: "4 ft", lo: 4, hi: 4},
+		{in: "Seas 2 feet or less", lo: 0, hi: 2},
+		{in: "Flat", lo: -1, hi: -1},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.in, func(t *testing.T) {
+			lo, hi := parseFeetRange(tt.in)
+			if deref(lo) != tt.lo || deref(hi) != tt.hi {
+				t.Fatalf("Returned range did not match expected range:\n\tReturned: %v-%v\n\tExpected: %v-%v", deref(lo), deref(hi), tt.lo, tt.hi)
+			}
+		})
+	}
+}
+
+func TestMarineZoneForecast(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/points/46.78,-92.09":
+			fmt.Fprint(w, `{"properties": {"gridId": "DLH", "gridX": 1, "gridY": 2}}`)
+		case "/zones":
+			fmt.Fprintf(w, `{"features": [{"id": "%s/zones/marine/LSZ145"}]}`, srv.URL)
+		case "/zones/marine/LSZ145":
+			fmt.Fprint(w, `{"properties": {"name": "Two Harbors to Duluth MN", "cwa": ["DLH"]}}`)
+		case "/products/types/NSH/locations/DLH":
+			fmt.Fprintf(w, `{"@graph": [{"id": "%s/products/abc-123"}]}`, srv.URL)
+		case "/products/abc-123":
+			b, _ := json.Marshal(nwsProduct{IssuanceTime: "2026-10-16T15:30:00+00:00", ProductText: testNshProduct})
+			w.Write(b)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewGridPointCache("")
+	c.base = srv.URL
+	s := &spot.Spot{Name: "Park Point", Latitude: 46.7767, Longitude: -92.0911, Timezone: "America/Chicago"}
+
+	f, err := c.marineZoneForecast(t.Context(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Zone != "LSZ145" || f.Product != "NSH" || f.Office != "DLH" || len(f.Periods) != 3 {
+		t.Fatalf("unexpected forecast: %+v", f)
+	}
+	if f.Issued != "2026-10-16T10:30:00-05:00" {
+		t.Fatalf("Returned issued time did not match expected issued time:\n\tReturned: %s\n\tExpected: %s", f.Issued, "2026-10-16T10:30:00-05:00")
+	}
+}
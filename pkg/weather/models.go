@@ -0,0 +1,410 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+// defaultCompareDays keeps the comparison to the horizon where the models
+// still carry useful skill and the response stays small.
+const defaultCompareDays = 3
+
+// minSpreadBaseFt is the smallest mean height spreads are measured against,
+// so that a few inches of disagreement on a flat day doesn't read as low
+// confidence.
+const minSpreadBaseFt = 1.0
+
+// Confidence labels of a model comparison.
+const (
+	ConfidenceHigh     = "high"
+	ConfidenceModerate = "moderate"
+	ConfidenceLow      = "low"
+)
+
+// waveModel is an Open-Meteo wave model compared by CompareWaveModels.
+type waveModel struct {
+	ID   string
+	Name string
+}
+
+var waveModels = []waveModel{
+	{"ecmwf_wam025", "ECMWF WAM"},
+	{"ncep_gfswave025", "GFS Wave"},
+	{"dwd_gwam", "DWD ICON GWAM"},
+	{"meteofrance_wave", "MeteoFrance MFWAM"},
+}
+
+// waveModelVars are the hourly variables compared across models.
+var waveModelVars = []string{"wave_height", "wave_period", "wave_direction"}
+
+type CompareWaveModelsArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	Start        string `json:"start,omitempty" jsonschema_description:"First hour to compare, as RFC3339 or as 'YYYY-MM-DD' or 'YYYY-MM-DDTHH:MM' in the spot's timezone."`
+	End          string `json:"end,omitempty" jsonschema_description:"Last hour to compare (inclusive), in the same formats as start. A bare date includes that whole day."`
+	ForecastDays int    `json:"forecast_days,omitempty" jsonschema_description:"Days of forecast to fetch, 1-16. Defaults to 3."`
+	StepHours    int    `json:"step_hours,omitempty" jsonschema_description:"Return every Nth hour, e.g. 3 for 3-hourly. Defaults to 1."`
+}
+
+// WaveModelComparison is several wave models' forecasts for a spot side by
+// side, with their spread and an overall confidence.
+type WaveModelComparison struct {
+	Latitude      float64         `json:"latitude" jsonschema_description:"Latitude of the marine grid cell compared."`
+	Longitude     float64         `json:"longitude" jsonschema_description:"Longitude of the marine grid cell compared."`
+	OffshoreMiles float64         `json:"offshore_miles" jsonschema_description:"How far seaward of the spot, along its facing direction, the models were queried because the spot's own grid cell is land."`
+	Timezone      string          `json:"timezone" jsonschema_description:"IANA timezone of the returned times."`
+	Models        []WaveModelInfo `json:"models"`
+	Hours         []ModelHour     `json:"hours" jsonschema_description:"Each model's values per hour, keyed by model id. Models without data for an hour are left out of it."`
+	Agreement     ModelAgreement  `json:"agreement"`
+}
+
+type WaveModelInfo struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Hours int    `json:"hours" jsonschema_description:"Number of compared hours the model has a wave height for. 0 when it doesn't cover the spot."`
+}
+
+type ModelHour struct {
+	Time                time.Time          `json:"time" jsonschema_description:"Start of the hour, RFC3339 in the spot's timezone."`
+	WaveHeight          map[string]float64 `json:"wave_height" jsonschema_description:"Significant wave height in feet by model id."`
+	WavePeriod          map[string]float64 `json:"wave_period" jsonschema_description:"Mean wave period in seconds by model id."`
+	WaveDirection       map[string]float64 `json:"wave_direction" jsonschema_description:"Mean wave direction in degrees true by model id."`
+	MeanWaveHeight      *float64           `json:"mean_wave_height,omitempty" jsonschema_description:"Mean wave height across models in feet."`
+	WaveHeightSpread    *float64           `json:"wave_height_spread,omitempty" jsonschema_description:"Highest minus lowest model wave height in feet."`
+	WavePeriodSpread    *float64           `json:"wave_period_spread,omitempty" jsonschema_description:"Longest minus shortest model wave period in seconds."`
+	WaveDirectionSpread *float64           `json:"wave_direction_spread,omitempty" jsonschema_description:"Largest angle in degrees between any two models' wave directions."`
+}
+
+// ModelAgreement summarizes how closely the models agree over the compared
+// hours.
+type ModelAgreement struct {
+	Confidence        float64 `json:"confidence" jsonschema_description:"0 to 1, where 1 means every model forecasts the same wave height every hour."`
+	Label             string  `json:"label" jsonschema_description:"'high', 'moderate' or 'low' confidence."`
+	MeanHeightSpread  float64 `json:"mean_height_spread" jsonschema_description:"Mean hourly wave height spread in feet."`
+	MaxHeightSpread   float64 `json:"max_height_spread" jsonschema_description:"Largest hourly wave height spread in feet."`
+	HighestModel      string  `json:"highest_model,omitempty" jsonschema_description:"Model forecasting the biggest waves on average."`
+	LowestModel       string  `json:"lowest_model,omitempty" jsonschema_description:"Model forecasting the smallest waves on average."`
+	HighLowDifference float64 `json:"high_low_difference" jsonschema_description:"How much higher the highest model runs than the lowest, in feet averaged over the hours both cover."`
+	Summary           string  `json:"summary" jsonschema_description:"One-line description of the agreement, e.g. 'low confidence, GFS Wave runs 2.0 ft higher than ECMWF WAM'."`
+}
+
+// CompareWaveModels fetches several Open-Meteo wave models for the spot and
+// compares their hourly wave height, period and direction.
+func CompareWaveModels(ctx tool.Context, a *CompareWaveModelsArgs) (*WaveModelComparison, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	loc := a.Spot.Location()
+	start, err := parseWindowTime(a.Start, loc, false)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseWindowTime(a.End, loc, true)
+	if err != nil {
+		return nil, err
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, fmt.Errorf("end %s is before start %s: %w", a.End, a.Start, ErrInvalidForecastWindow)
+	}
+
+	days := a.ForecastDays
+	if days == 0 {
+		days = defaultCompareDays
+	}
+	cmp, miles, err := seaward(a.Spot, func(lat, lon float64) (*WaveModelComparison, bool, error) {
+		cmp, err := fetchWaveModels(lat, lon, loc, days)
+		if err != nil {
+			return nil, false, err
+		}
+		return cmp, cmp.hasWaves(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	cmp.OffshoreMiles = miles
+
+	hours := make([]ModelHour, 0, len(cmp.Hours))
+	for _, h := range cmp.Hours {
+		if inWindow(h.Time, start, end) {
+			hours = append(hours, h)
+		}
+	}
+	hours = everyHour(hours, max(a.StepHours, 1), func(h ModelHour) time.Time { return h.Time })
+	cmp.Hours = hours
+
+	for i := range cmp.Models {
+		for _, h := range hours {
+			if _, ok := h.WaveHeight[cmp.Models[i].ID]; ok {
+				cmp.Models[i].Hours++
+			}
+		}
+	}
+	cmp.Agreement = agreement(hours)
+	return cmp, nil
+}
+
+func (a *CompareWaveModelsArgs) validate() error {
+	switch {
+	case a.Spot == nil:
+		return fmt.Errorf("spot is required: %w", ErrInvalidArgs)
+	case a.ForecastDays < 0 || a.ForecastDays > maxMarineForecastDays:
+		return fmt.Errorf("forecast_days must be between 1 and %d: %w", maxMarineForecastDays, ErrInvalidForecastWindow)
+	case a.StepHours < 0:
+		return fmt.Errorf("step_hours must be positive: %w", ErrInvalidForecastWindow)
+	}
+	return nil
+}
+
+// fetchWaveModels fetches every compared model for the grid cell containing
+// the coordinate.
+func fetchWaveModels(lat, lon float64, loc *time.Location, days int) (*WaveModelComparison, error) {
+	ids := make([]string, 0, len(waveModels))
+	for _, m := range waveModels {
+		ids = append(ids, m.ID)
+	}
+	u := marineQueryUrl(lat, lon, loc, waveModelVars) + fmt.Sprintf("&forecast_days=%d&models=%s", days, strings.Join(ids, ","))
+
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrInvalidHttpResponse
+	}
+
+	resBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw openMeteoMarineResp
+	if err := json.Unmarshal(resBody, &raw); err != nil {
+		return nil, err
+	}
+	return raw.modelRows(loc)
+}
+
+// modelRows converts a multi-model response, whose hourly variables are
+// suffixed with the model id, into per-hour comparisons.
+func (r *openMeteoMarineResp) modelRows(loc *time.Location) (*WaveModelComparison, error) {
+	var times []string
+	if err := json.Unmarshal(r.Hourly["time"], &times); err != nil {
+		return nil, fmt.Errorf("decoding Open-Meteo hourly times: %w", err)
+	}
+
+	cmp := &WaveModelComparison{
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Timezone:  loc.String(),
+		Models:    make([]WaveModelInfo, 0, len(waveModels)),
+		Hours:     make([]ModelHour, 0, len(times)),
+	}
+	for _, ts := range times {
+		t, err := time.ParseInLocation(openMeteoTimeFormat, ts, loc)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Open-Meteo time %q: %w", ts, err)
+		}
+		cmp.Hours = append(cmp.Hours, ModelHour{
+			Time:          t,
+			WaveHeight:    make(map[string]float64),
+			WavePeriod:    make(map[string]float64),
+			WaveDirection: make(map[string]float64),
+		})
+	}
+
+	for _, m := range waveModels {
+		cmp.Models = append(cmp.Models, WaveModelInfo{ID: m.ID, Name: m.Name})
+		for _, v := range waveModelVars {
+			raw, ok := r.Hourly[v+"_"+m.ID]
+			if !ok {
+				continue
+			}
+			var values []*float64
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return nil, fmt.Errorf("decoding Open-Meteo %s_%s: %w", v, m.ID, err)
+			}
+			for i := range min(len(values), len(cmp.Hours)) {
+				if values[i] != nil {
+					cmp.Hours[i].values(v)[m.ID] = *values[i]
+				}
+			}
+		}
+	}
+
+	for i := range cmp.Hours {
+		cmp.Hours[i].spread()
+	}
+	return cmp, nil
+}
+
+func (h *ModelHour) values(v string) map[string]float64 {
+	switch v {
+	case "wave_height":
+		return h.WaveHeight
+	case "wave_period":
+		return h.WavePeriod
+	default:
+		return h.WaveDirection
+	}
+}
+
+// hasWaves reports whether any model has a wave height for the cell.
+func (c *WaveModelComparison) hasWaves() bool {
+	for _, h := range c.Hours {
+		if len(h.WaveHeight) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// spread fills in the hour's cross-model mean and spreads.
+func (h *ModelHour) spread() {
+	round := func(v float64) *float64 {
+		v = math.Round(v*100) / 100
+		return &v
+	}
+
+	if len(h.WaveHeight) > 0 {
+		lo, hi, sum := valueRange(h.WaveHeight)
+		h.MeanWaveHeight = round(sum / float64(len(h.WaveHeight)))
+		h.WaveHeightSpread = round(hi - lo)
+	}
+	if len(h.WavePeriod) > 0 {
+		lo, hi, _ := valueRange(h.WavePeriod)
+		h.WavePeriodSpread = round(hi - lo)
+	}
+	if len(h.WaveDirection) > 0 {
+		widest := 0.0
+		for _, a := range h.WaveDirection {
+			for _, b := range h.WaveDirection {
+				widest = math.Max(widest, angleBetween(a, b))
+			}
+		}
+		h.WaveDirectionSpread = round(widest)
+	}
+}
+
+func valueRange(values map[string]float64) (lo, hi, sum float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi, sum = math.Min(lo, v), math.Max(hi, v), sum+v
+	}
+	return lo, hi, sum
+}
+
+// angleBetween returns the smallest angle between two directions in degrees.
+func angleBetween(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	return math.Min(d, 360-d)
+}
+
+// agreement scores how closely the models' wave heights agree. Each hour's
+// spread is taken relative to its mean height, and confidence is one minus
+// the average relative spread.
+func agreement(hours []ModelHour) ModelAgreement {
+	var a ModelAgreement
+	var relSum, spreadSum float64
+	n := 0
+	for _, h := range hours {
+		if len(h.WaveHeight) < 2 {
+			continue
+		}
+		spread := *h.WaveHeightSpread
+		relSum += math.Min(1, spread/math.Max(*h.MeanWaveHeight, minSpreadBaseFt))
+		spreadSum += spread
+		a.MaxHeightSpread = math.Max(a.MaxHeightSpread, spread)
+		n++
+	}
+	if n == 0 {
+		a.Label = ConfidenceLow
+		a.Summary = "low confidence, fewer than two models cover the spot"
+		return a
+	}
+
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	a.Confidence = round(1 - relSum/float64(n))
+	a.MeanHeightSpread = round(spreadSum / float64(n))
+	a.MaxHeightSpread = round(a.MaxHeightSpread)
+	switch {
+	case a.Confidence >= 0.75:
+		a.Label = ConfidenceHigh
+	case a.Confidence >= 0.5:
+		a.Label = ConfidenceModerate
+	default:
+		a.Label = ConfidenceLow
+	}
+
+	hi, lo, diff := modelBias(hours)
+	a.HighestModel, a.LowestModel, a.HighLowDifference = hi, lo, round(diff)
+	if a.Label == ConfidenceHigh {
+		a.Summary = fmt.Sprintf("high confidence, models agree within %.1f ft on average", a.MeanHeightSpread)
+	} else {
+		a.Summary = fmt.Sprintf("%s confidence, %s runs %.1f ft higher than %s", a.Label, modelName(hi), diff, modelName(lo))
+	}
+	return a
+}
+
+// modelBias finds the models forecasting the biggest and smallest waves on
+// average, and how far apart they are over the hours both cover.
+func modelBias(hours []ModelHour) (highest, lowest string, diff float64) {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, h := range hours {
+		if len(h.WaveHeight) < 2 {
+			continue
+		}
+		for id, v := range h.WaveHeight {
+			sums[id] += v - *h.MeanWaveHeight
+			counts[id]++
+		}
+	}
+
+	hiBias, loBias := math.Inf(-1), math.Inf(1)
+	for _, m := range waveModels {
+		if counts[m.ID] == 0 {
+			continue
+		}
+		bias := sums[m.ID] / float64(counts[m.ID])
+		if bias > hiBias {
+			highest, hiBias = m.ID, bias
+		}
+		if bias < loBias {
+			lowest, loBias = m.ID, bias
+		}
+	}
+
+	var total float64
+	n := 0
+	for _, h := range hours {
+		hv, hok := h.WaveHeight[highest]
+		lv, lok := h.WaveHeight[lowest]
+		if hok && lok {
+			total += hv - lv
+			n++
+		}
+	}
+	if n > 0 {
+		diff = total / float64(n)
+	}
+	return highest, lowest, diff
+}
+
+func modelName(id string) string {
+	for _, m := range waveModels {
+		if m.ID == id {
+			return m.Name
+		}
+	}
+	return id
+}
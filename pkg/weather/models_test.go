@@ -0,0 +1,94 @@
+package weather
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestWaveModelAgreement(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ecmwf      string
+		gfs        string
+		label      string
+		highest    string
+		difference float64
+	}{
+		{
+			name:       "models agree",
+			ecmwf:      "[3.0, 3.2, 3.4]",
+			gfs:        "[3.1, 3.3, 3.4]",
+			label:      ConfidenceHigh,
+			highest:    "ncep_gfswave025",
+			difference: 0.07,
+		},
+		{
+			name:       "GFS runs high",
+			ecmwf:      "[2.0, 2.5, 3.0]",
+			gfs:        "[4.0, 4.5, 5.0]",
+			label:      ConfidenceLow,
+			highest:    "ncep_gfswave025",
+			difference: 2,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{
+				"latitude": 32.75, "longitude": -117.25,
+				"hourly": {
+					"time": ["2026-10-17T05:00", "2026-10-17T06:00", "2026-10-17T07:00"],
+					"wave_height_ecmwf_wam025": ` + tt.ecmwf + `,
+					"wave_height_ncep_gfswave025": ` + tt.gfs + `,
+					"wave_height_dwd_gwam": [null, null, null],
+					"wave_direction_ecmwf_wam025": [350, 355, 0],
+					"wave_direction_ncep_gfswave025": [10, 15, 20]
+				}
+			}`
+			var raw openMeteoMarineResp
+			if err := json.Unmarshal([]byte(body), &raw); err != nil {
+				t.Fatal(err)
+			}
+			cmp, err := raw.modelRows(time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := cmp.Hours[0]
+			if len(h.WaveHeight) != 2 || *h.WaveDirectionSpread != 20 {
+				t.Fatalf("expected two models 20° apart in the first hour, got %+v", h)
+			}
+
+			a := agreement(cmp.Hours)
+			if a.Label != tt.label || a.HighestModel != tt.highest || a.LowestModel != "ecmwf_wam025" {
+				t.Fatalf("Returned agreement did not match expected agreement:\n\tReturned: %+v\n\tExpected: %s confidence, %s highest", a, tt.label, tt.highest)
+			}
+			if a.HighLowDifference != tt.difference {
+				t.Fatalf("Returned difference did not match expected difference:\n\tReturned: %v\n\tExpected: %v", a.HighLowDifference, tt.difference)
+			}
+		})
+	}
+}
+
+func TestWaveModelAgreementSingleModel(t *testing.T) {
+	v := 3.0
+	hours := []ModelHour{{WaveHeight: map[string]float64{"ecmwf_wam025": v}, MeanWaveHeight: &v}}
+	if a := agreement(hours); a.Label != ConfidenceLow || a.Confidence != 0 {
+		t.Fatalf("expected low confidence with a single model, got %+v", a)
+	}
+}
+
+func TestCompareWaveModelsReversedWindow(t *testing.T) {
+	a := &CompareWaveModelsArgs{
+		Spot:  &spot.Spot{Name: "Ocean Beach", Timezone: "America/Los_Angeles"},
+		Start: "2026-10-18",
+		End:   "2026-10-17",
+	}
+	if _, err := CompareWaveModels(nil, a); !errors.Is(err, ErrInvalidForecastWindow) {
+		t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrInvalidForecastWindow)
+	}
+}
@@ -0,0 +1,281 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+const ndbcStationsUrl = "https://www.ndbc.noaa.gov/activestations.xml"
+
+// staleStationMiles is how far a configured station may be from its spot
+// before it is flagged as a poor fit.
+const staleStationMiles = 100
+
+// catalogRetryBackoff is how long a failed live station list fetch is
+// remembered before it is tried again, so an unreachable service doesn't
+// stall every lookup behind a fresh timeout.
+const catalogRetryBackoff = 10 * time.Minute
+
+//go:generate curl -sSfo data/ndbc_activestations.xml https://www.ndbc.noaa.gov/activestations.xml
+//go:embed data/ndbc_activestations.xml
+var bundledNdbcStations []byte
+
+// NdbcStation is a single entry from the NDBC active station list.
+type NdbcStation struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Owner string  `json:"owner"`
+	Type  string  `json:"type" jsonschema_description:"NDBC station type: 'buoy' for moored buoys, 'fixed' for C-MAN and shore stations, 'dart' for tsunami buoys."`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+	Met   bool    `json:"met" jsonschema_description:"Whether the station reports meteorological data such as wind."`
+}
+
+// ReportsWaves reports whether the station measures waves. Moored buoys do;
+// C-MAN and shore stations only report wind and pressure.
+func (s NdbcStation) ReportsWaves() bool {
+	return s.Type == "buoy"
+}
+
+// ReportsWind reports whether the station measures wind.
+func (s NdbcStation) ReportsWind() bool {
+	return s.Met
+}
+
+// NdbcCatalog is a searchable set of NDBC stations.
+type NdbcCatalog struct {
+	stations []NdbcStation
+	byID     map[string]NdbcStation
+}
+
+// raw types for XML decoding
+
+type ndbcStationList struct {
+	Stations []ndbcStationXML `xml:"station"`
+}
+
+type ndbcStationXML struct {
+	ID    string  `xml:"id,attr"`
+	Lat   float64 `xml:"lat,attr"`
+	Lon   float64 `xml:"lon,attr"`
+	Name  string  `xml:"name,attr"`
+	Owner string  `xml:"owner,attr"`
+	Type  string  `xml:"type,attr"`
+	Met   string  `xml:"met,attr"`
+}
+
+// ParseNdbcStations parses the NDBC activestations.xml format.
+// https://www.ndbc.noaa.gov/docs/ndbc_web_data_guide.pdf
+func ParseNdbcStations(r io.Reader) (*NdbcCatalog, error) {
+	var raw ndbcStationList
+	if err := xml.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parsing NDBC station list: %w", err)
+	}
+
+	c := &NdbcCatalog{
+		stations: make([]NdbcStation, 0, len(raw.Stations)),
+		byID:     make(map[string]NdbcStation, len(raw.Stations)),
+	}
+	for _, s := range raw.Stations {
+		st := NdbcStation{
+			ID:    strings.ToUpper(s.ID),
+			Name:  s.Name,
+			Owner: s.Owner,
+			Type:  s.Type,
+			Lat:   s.Lat,
+			Lon:   s.Lon,
+			Met:   s.Met == "y",
+		}
+		c.stations = append(c.stations, st)
+		c.byID[st.ID] = st
+	}
+	return c, nil
+}
+
+var (
+	ndbcCatalogOnce sync.Once
+	ndbcCatalog     *NdbcCatalog
+
+	liveNdbcMu      sync.Mutex
+	liveNdbcCatalog *NdbcCatalog
+	liveNdbcFailed  time.Time
+)
+
+// BundledNdbcCatalog returns the station snapshot compiled into the binary.
+func BundledNdbcCatalog() *NdbcCatalog {
+	ndbcCatalogOnce.Do(func() {
+		c, err := ParseNdbcStations(bytes.NewReader(bundledNdbcStations))
+		if err != nil {
+			panic(err)
+		}
+		ndbcCatalog = c
+	})
+	return ndbcCatalog
+}
+
+// FetchNdbcCatalog downloads the current NDBC active station list.
+func FetchNdbcCatalog(ctx context.Context) (*NdbcCatalog, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", ndbcStationsUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching NDBC station list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrInvalidHttpResponse
+	}
+	return ParseNdbcStations(resp.Body)
+}
+
+// Lookup returns the station with the given ID.
+func (c *NdbcCatalog) Lookup(id string) (NdbcStation, bool) {
+	s, ok := c.byID[strings.ToUpper(id)]
+	return s, ok
+}
+
+// Nearest returns the closest station to a coordinate that satisfies keep,
+// along with its distance in miles. ok is false when no station qualifies.
+func (c *NdbcCatalog) Nearest(lat, lon float64, keep func(NdbcStation) bool) (st NdbcStation, miles float64, ok bool) {
+	miles = math.Inf(1)
+	for _, s := range c.stations {
+		if !keep(s) {
+			continue
+		}
+		if d := spot.DistanceMiles(lat, lon, s.Lat, s.Lon); d < miles {
+			st, miles, ok = s, d, true
+		}
+	}
+	return st, miles, ok
+}
+
+// NdbcSuggestion is a recommended station for a spot.
+type NdbcSuggestion struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	DistanceMiles float64 `json:"distance_miles"`
+}
+
+// NdbcStationReport checks a spot's configured stations against the station
+// catalog and suggests better fits.
+type NdbcStationReport struct {
+	Spot               string             `json:"spot"`
+	ConfiguredID       string             `json:"configured_id" jsonschema_description:"The spot's current nearest_buoy_id. Empty when unset."`
+	Stations           []spot.BuoyStation `json:"stations" jsonschema_description:"Every station the spot reads observations from, with its role and weight."`
+	NearestWaveBuoy    *NdbcSuggestion    `json:"nearest_wave_buoy" jsonschema_description:"Closest station that reports waves. Null when the catalog has none."`
+	NearestWindStation *NdbcSuggestion    `json:"nearest_wind_station" jsonschema_description:"Closest station that reports wind. Null when the catalog has none."`
+	Warnings           []string           `json:"warnings" jsonschema_description:"Problems with the configured station, e.g. unknown ID, no wave data, or too far from the spot. Empty when it looks fine."`
+}
+
+// CheckNdbcStation reports whether each of the spot's configured stations is
+// still in the catalog, whether it measures what its role needs, and which
+// stations are nearest.
+func (c *NdbcCatalog) CheckNdbcStation(s *spot.Spot) NdbcStationReport {
+	lat, lon := float64(s.Latitude), float64(s.Longitude)
+	r := NdbcStationReport{
+		Spot:         s.Name,
+		ConfiguredID: s.NearestBuoyID,
+		Stations:     s.Stations(),
+		Warnings:     []string{},
+	}
+	if r.Stations == nil {
+		r.Stations = []spot.BuoyStation{}
+	}
+
+	if st, d, ok := c.Nearest(lat, lon, NdbcStation.ReportsWaves); ok {
+		r.NearestWaveBuoy = newNdbcSuggestion(st, d)
+	}
+	if st, d, ok := c.Nearest(lat, lon, NdbcStation.ReportsWind); ok {
+		r.NearestWindStation = newNdbcSuggestion(st, d)
+	}
+
+	if len(r.Stations) == 0 {
+		r.Warnings = append(r.Warnings, "no buoy configured")
+		return r
+	}
+
+	for _, b := range r.Stations {
+		st, ok := c.Lookup(b.ID)
+		if !ok {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("station %s is not in the NDBC active station list", b.ID))
+			continue
+		}
+		switch {
+		case b.Role == spot.RoleWind && !st.ReportsWind():
+			r.Warnings = append(r.Warnings, fmt.Sprintf("station %s (%s) is used for wind but does not report it", st.ID, st.Name))
+		case b.Role != spot.RoleWind && !st.ReportsWaves():
+			r.Warnings = append(r.Warnings, fmt.Sprintf("station %s (%s) is a %s station and does not report waves", st.ID, st.Name, st.Type))
+		}
+		// Upstream swell buoys are expected to be far out along the swell path.
+		if d := s.DistanceTo(st.Lat, st.Lon); d > staleStationMiles && b.Role != spot.RoleUpstreamSwell {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("station %s is %.0f miles from the spot", st.ID, d))
+		}
+	}
+	return r
+}
+
+func newNdbcSuggestion(st NdbcStation, miles float64) *NdbcSuggestion {
+	return &NdbcSuggestion{
+		ID:            st.ID,
+		Name:          st.Name,
+		Type:          st.Type,
+		DistanceMiles: math.Round(miles*10) / 10,
+	}
+}
+
+// loadNdbcCatalog prefers the live station list, fetched once per process, and
+// falls back to the bundled snapshot when NDBC can't be reached. A failed
+// fetch isn't retried until catalogRetryBackoff has passed.
+func loadNdbcCatalog(ctx context.Context) *NdbcCatalog {
+	liveNdbcMu.Lock()
+	defer liveNdbcMu.Unlock()
+
+	if liveNdbcCatalog != nil {
+		return liveNdbcCatalog
+	}
+	if ctx == nil || time.Since(liveNdbcFailed) < catalogRetryBackoff {
+		return BundledNdbcCatalog()
+	}
+	c, err := FetchNdbcCatalog(ctx)
+	if err != nil {
+		liveNdbcFailed = time.Now()
+		return BundledNdbcCatalog()
+	}
+	liveNdbcCatalog = c
+	return c
+}
+
+// CheckNdbcStations checks every spot's configured stations against the NDBC
+// station catalog.
+func CheckNdbcStations(ctx context.Context, spots []spot.Spot) []NdbcStationReport {
+	c := loadNdbcCatalog(ctx)
+	reports := make([]NdbcStationReport, 0, len(spots))
+	for i := range spots {
+		reports = append(reports, c.CheckNdbcStation(&spots[i]))
+	}
+	return reports
+}
+
+// SuggestNdbcStations checks the spot's configured stations and suggests the
+// nearest wave-reporting buoy and wind station.
+func SuggestNdbcStations(ctx tool.Context, s *spot.Spot) (*NdbcStationReport, error) {
+	r := loadNdbcCatalog(ctx).CheckNdbcStation(s)
+	return &r, nil
+}
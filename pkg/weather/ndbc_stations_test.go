@@ -0,0 +1,119 @@
+package weather
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestCheckNdbcStation(t *testing.T) {
+	testCases := []struct {
+		spot         *spot.Spot
+		expectedWave string
+		expectWarn   bool
+	}{
+		{
+			spot: &spot.Spot{
+				Name:          "Ocean Beach",
+				Latitude:      32.7487318,
+				Longitude:     -117.2583427,
+				NearestBuoyID: "46086",
+			},
+			expectedWave: "46254",
+			expectWarn:   false,
+		},
+		{
+			spot: &spot.Spot{
+				Name:          "Stoney Point",
+				Latitude:      46.9666696,
+				Longitude:     -91.6359906,
+				NearestBuoyID: "SLVM5",
+			},
+			expectedWave: "45028",
+			expectWarn:   true,
+		},
+		{
+			spot: &spot.Spot{
+				Name:      "Stoney Point (wind station)",
+				Latitude:  46.9666696,
+				Longitude: -91.6359906,
+				Buoys: []spot.BuoyStation{
+					{ID: "45028", Role: spot.RoleWaves, Weight: 1},
+					{ID: "SLVM5", Role: spot.RoleWind, Weight: 1},
+				},
+			},
+			expectedWave: "45028",
+			expectWarn:   false,
+		},
+		{
+			spot: &spot.Spot{
+				Name:          "Mordor",
+				Latitude:      46.9666696,
+				Longitude:     -91.6359906,
+				NearestBuoyID: "ZZZZ9",
+			},
+			expectedWave: "45028",
+			expectWarn:   true,
+		},
+	}
+
+	c := BundledNdbcCatalog()
+	for _, tt := range testCases {
+		t.Run(tt.spot.Name, func(t *testing.T) {
+			r := c.CheckNdbcStation(tt.spot)
+			if r.NearestWaveBuoy == nil || r.NearestWaveBuoy.ID != tt.expectedWave {
+				t.Fatalf("Expected nearest wave buoy %s, got %+v", tt.expectedWave, r.NearestWaveBuoy)
+			}
+			if r.NearestWindStation == nil {
+				t.Fatal("expected a nearest wind station")
+			}
+			if got := len(r.Warnings) > 0; got != tt.expectWarn {
+				t.Fatalf("Expected warnings=%v, got %v", tt.expectWarn, r.Warnings)
+			}
+		})
+	}
+}
+
+func TestNdbcStationCapabilities(t *testing.T) {
+	c := BundledNdbcCatalog()
+	for _, id := range []string{"BSBM4", "SLVM5"} {
+		st, ok := c.Lookup(id)
+		if !ok {
+			t.Fatalf("expected %s in bundled catalog", id)
+		}
+		if st.ReportsWaves() || !st.ReportsWind() {
+			t.Errorf("%s: C-MAN stations report wind but not waves", id)
+		}
+	}
+
+	var waveOnly []string
+	for _, st := range c.stations {
+		if st.ReportsWaves() && !st.ReportsWind() {
+			waveOnly = append(waveOnly, st.ID)
+		}
+	}
+	if !slices.Contains(waveOnly, "46254") {
+		t.Errorf("expected CDIP buoy 46254 to report waves without wind, got %v", waveOnly)
+	}
+}
+
+func TestLoadNdbcCatalogBacksOff(t *testing.T) {
+	t.Cleanup(func() { liveNdbcFailed = time.Time{} })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if c := loadNdbcCatalog(ctx); c != BundledNdbcCatalog() {
+		t.Fatal("expected the bundled catalog when the live fetch fails")
+	}
+	failed := liveNdbcFailed
+	if failed.IsZero() {
+		t.Fatal("expected the failed fetch to be remembered")
+	}
+
+	if c := loadNdbcCatalog(ctx); c != BundledNdbcCatalog() || !liveNdbcFailed.Equal(failed) {
+		t.Fatal("expected the live fetch not to be retried within the backoff")
+	}
+}
@@ -5,9 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/louislef299/wave-report-agent/pkg/spot"
 	"google.golang.org/adk/tool"
@@ -20,6 +18,8 @@ const (
 
 var ErrInvalidHttpResponse = errors.New("received an invalid HTTP response")
 
+var ErrInvalidArgs = errors.New("invalid tool arguments")
+
 type GridResp struct {
 	Properties GridRespProperties `json:"properties"`
 }
@@ -30,35 +30,60 @@ type GridRespProperties struct {
 
 type GridRespPeriod struct {
 	Name          string `json:"name"`
+	StartTime     string `json:"startTime" jsonschema_description:"Start of the period in RFC3339, in the spot's timezone."`
+	EndTime       string `json:"endTime" jsonschema_description:"End of the period in RFC3339, in the spot's timezone."`
 	Temperature   int32  `json:"temperature"`
 	WindSpeed     string `json:"windSpeed"`
 	WindDirection string `json:"windDirection"`
 	Forecast      string `json:"detailedForecast"`
+
+	ShortForecast              string    `json:"shortForecast,omitempty"`
+	ProbabilityOfPrecipitation *NwsValue `json:"probabilityOfPrecipitation,omitempty" jsonschema_description:"Chance of precipitation in percent."`
+
+	// WindGustText is NWS's separate gust text, which only some offices send.
+	WindGustText     string   `json:"windGust,omitempty"`
+	WindSpeedMin     *float64 `json:"windSpeedMin,omitempty" jsonschema_description:"Lowest sustained wind speed in windSpeed, in windUnit."`
+	WindSpeedMax     *float64 `json:"windSpeedMax,omitempty" jsonschema_description:"Highest sustained wind speed in windSpeed, in windUnit. Equals windSpeedMin for a single speed."`
+	WindGustSpeed    *float64 `json:"windGustSpeed,omitempty" jsonschema_description:"Gust speed in windUnit, when NWS states one."`
+	WindUnit         string   `json:"windUnit,omitempty" jsonschema_description:"Unit of the parsed wind speeds: 'mph', 'kt' or 'km/h'."`
+	WindDirectionDeg *float64 `json:"windDirectionDeg,omitempty" jsonschema_description:"windDirection in degrees true (where the wind blows from)."`
+}
+
+type NwsForecastArgs struct {
+	Spot     *spot.Spot `json:"spot"`
+	WindUnit string     `json:"wind_unit,omitempty" jsonschema_description:"Unit for the parsed wind speeds: 'mph' (default), 'kt' or 'km/h'."`
 }
 
 // GetNwsForecast gathers the 7-day forecast over 12 hour periods by calling the
-// National Weather Service API and returning a GridResp.
+// National Weather Service API and returning a GridResp, with wind speeds
+// parsed into the requested unit. The forecast URL comes
+// from Meta[spot.MetaNwsGridPoint] when set, and otherwise from the persisted
+// gridpoint cache.
 // https://www.weather.gov/documentation/services-web-api
-func GetNwsForecast(ctx tool.Context, s *spot.Spot) (*GridResp, error) {
-	var err error
-	forecastURL, ok := s.Meta[spot.MetaNwsGridPoint]
-	if !ok {
-		forecastURL, err = GatherGridPoint(ctx, s)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	f, ok := forecastURL.(string)
-	if !ok {
-		return nil, fmt.Errorf("didn't get expected metadata return type of string")
+func GetNwsForecast(ctx tool.Context, a *NwsForecastArgs) (*GridResp, error) {
+	if a.Spot == nil {
+		return nil, fmt.Errorf("spot is required: %w", ErrInvalidArgs)
 	}
-	resp, err := http.Get(f)
+	unit, err := validWindUnit(a.WindUnit)
 	if err != nil {
 		return nil, err
 	}
 
-	resBody, err := io.ReadAll(resp.Body)
+	s := a.Spot
+	var (
+		resBody []byte
+	)
+	if override, ok := s.Meta[spot.MetaNwsGridPoint]; ok {
+		f, ok := override.(string)
+		if !ok {
+			return nil, fmt.Errorf("didn't get expected metadata return type of string")
+		}
+		resBody, err = getNwsProduct(ctx, f)
+	} else {
+		resBody, err = defaultGridPoints().fetch(ctx, s, func(gp *GridPoint) string {
+			return gp.Forecast
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -68,53 +93,25 @@ func GetNwsForecast(ctx tool.Context, s *spot.Spot) (*GridResp, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range gr.Properties.Periods {
+		p := &gr.Properties.Periods[i]
+		p.StartTime = localizeTimestamp(s, p.StartTime)
+		p.EndTime = localizeTimestamp(s, p.EndTime)
+		p.parseWind(unit)
+	}
 	return &gr, nil
 }
 
-type PointsResp struct {
-	Properties PointsRespProperties `json:"properties"`
-}
-
-type PointsRespProperties struct {
-	Forecast string `json:"forecast"`
-}
-
 // GatherGridPoint uses the Latitude and Longitude provided by the Spot to
 // gather the proper gridpoints(https://api.weather.gov/gridpoints) URL returned
 // as a string. This allows for detailed forecast information in future calls.
+// It always queries NWS; GetNwsForecast goes through the gridpoint cache.
 func GatherGridPoint(ctx context.Context, s *spot.Spot) (string, error) {
-	ll := fmt.Sprintf("%.2f,%.2f", s.Latitude, s.Longitude)
-	u, err := url.JoinPath(nwsBaseUrl, "points", ll)
-	if err != nil {
-		return "", err
-	}
-
-	req, err := generateNwsReq(ctx, u)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", ErrInvalidHttpResponse
-	}
-
-	resBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	var weatherResp PointsResp
-	err = json.Unmarshal(resBody, &weatherResp)
+	gp, err := NewGridPointCache("").lookup(ctx, s)
 	if err != nil {
 		return "", err
 	}
-	return weatherResp.Properties.Forecast, nil
+	return gp.Forecast, nil
 }
 
 // generateNwsReq generates a request to send to the National Weather Service
@@ -0,0 +1,330 @@
+package weather
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+var errInvalidIsoDuration = errors.New("invalid ISO 8601 duration")
+
+// NwsWindowArgs selects a spot and an optional window of an hourly NWS
+// product.
+type NwsWindowArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	Start string `json:"start,omitempty" jsonschema_description:"First hour to return, as RFC3339 or as 'YYYY-MM-DD' or 'YYYY-MM-DDTHH:MM' in the spot's timezone. Defaults to the first available hour."`
+	End   string `json:"end,omitempty" jsonschema_description:"Last hour to return (inclusive), in the same formats as start. A bare date includes that whole day. Defaults to the last available hour."`
+}
+
+func (a *NwsWindowArgs) window() (time.Time, time.Time, error) {
+	return spotWindow(a.Spot, a.Start, a.End)
+}
+
+type NwsHourlyForecastArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	Start    string `json:"start,omitempty" jsonschema_description:"First hour to return, as RFC3339 or as 'YYYY-MM-DD' or 'YYYY-MM-DDTHH:MM' in the spot's timezone. Defaults to the first available hour."`
+	End      string `json:"end,omitempty" jsonschema_description:"Last hour to return (inclusive), in the same formats as start. A bare date includes that whole day. Defaults to the last available hour."`
+	WindUnit string `json:"wind_unit,omitempty" jsonschema_description:"Unit for the parsed wind speeds: 'mph' (default), 'kt' or 'km/h'."`
+}
+
+// spotWindow parses window bounds in the spot's timezone.
+func spotWindow(s *spot.Spot, startStr, endStr string) (time.Time, time.Time, error) {
+	if s == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("spot is required: %w", ErrInvalidArgs)
+	}
+	loc := s.Location()
+	start, err := parseWindowTime(startStr, loc, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseWindowTime(endStr, loc, true)
+	return start, end, err
+}
+
+// NwsValue is a quantity with a WMO unit code as NWS reports it.
+type NwsValue struct {
+	UnitCode string   `json:"unitCode"`
+	Value    *float64 `json:"value" jsonschema_description:"Null when NWS has no value."`
+}
+
+// GetNwsHourlyForecast returns the NWS hourly forecast for the spot, with
+// period times in the spot's timezone and wind speeds parsed into the
+// requested unit.
+func GetNwsHourlyForecast(ctx tool.Context, a *NwsHourlyForecastArgs) (*GridResp, error) {
+	start, end, err := spotWindow(a.Spot, a.Start, a.End)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := validWindUnit(a.WindUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := defaultGridPoints().fetch(ctx, a.Spot, func(gp *GridPoint) string {
+		return gp.ForecastHourly
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var gr GridResp
+	if err := json.Unmarshal(resBody, &gr); err != nil {
+		return nil, err
+	}
+
+	periods := make([]GridRespPeriod, 0, len(gr.Properties.Periods))
+	for _, p := range gr.Properties.Periods {
+		if t, err := time.Parse(time.RFC3339, p.StartTime); err == nil && !inWindow(t, start, end) {
+			continue
+		}
+		p.StartTime = localizeTimestamp(a.Spot, p.StartTime)
+		p.EndTime = localizeTimestamp(a.Spot, p.EndTime)
+		p.parseWind(unit)
+		periods = append(periods, p)
+	}
+	gr.Properties.Periods = periods
+	return &gr, nil
+}
+
+// NwsGridData is the raw NWS gridpoint forecast as numeric hourly series.
+type NwsGridData struct {
+	Office   string        `json:"office"`
+	GridX    int           `json:"grid_x"`
+	GridY    int           `json:"grid_y"`
+	Timezone string        `json:"timezone" jsonschema_description:"IANA timezone of the hourly times, which are RFC3339 with the UTC offset included."`
+	Hours    []NwsGridHour `json:"hours" jsonschema_description:"One record per hour, in time order."`
+}
+
+// NwsGridHour is one hour of the raw NWS gridpoint forecast. Values NWS
+// doesn't forecast for the grid, such as wave height inland, are omitted.
+type NwsGridHour struct {
+	Time                 time.Time `json:"time" jsonschema_description:"Start of the hour, RFC3339 in the spot's timezone."`
+	TemperatureF         *float64  `json:"temperature_f,omitempty"`
+	WindSpeedMph         *float64  `json:"wind_speed_mph,omitempty" jsonschema_description:"Sustained wind speed in mph."`
+	WindGustMph          *float64  `json:"wind_gust_mph,omitempty"`
+	WindDirectionDeg     *float64  `json:"wind_direction_deg,omitempty" jsonschema_description:"Direction the wind blows from in degrees true."`
+	SkyCoverPct          *float64  `json:"sky_cover_pct,omitempty"`
+	PrecipProbabilityPct *float64  `json:"precip_probability_pct,omitempty"`
+	VisibilityMiles      *float64  `json:"visibility_miles,omitempty"`
+	WaveHeightFt         *float64  `json:"wave_height_ft,omitempty" jsonschema_description:"Significant wave height in feet. Only forecast for coastal and Great Lakes grids."`
+}
+
+// nwsLayer is a time series in the raw gridpoint response. Each value covers
+// an ISO 8601 interval such as 2026-10-17T05:00:00+00:00/PT3H.
+type nwsLayer struct {
+	Uom    string `json:"uom"`
+	Values []struct {
+		ValidTime string   `json:"validTime"`
+		Value     *float64 `json:"value"`
+	} `json:"values"`
+}
+
+type nwsGridResp struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// nwsGridLayer ties a raw gridpoint layer to its NwsGridHour field and the
+// conversion into that field's unit.
+type nwsGridLayer struct {
+	name    string
+	field   func(*NwsGridHour) **float64
+	convert func(uom string, v float64) (float64, bool)
+}
+
+var nwsGridLayers = []nwsGridLayer{
+	{"temperature", func(h *NwsGridHour) **float64 { return &h.TemperatureF }, toFahrenheit},
+	{"windSpeed", func(h *NwsGridHour) **float64 { return &h.WindSpeedMph }, toMph},
+	{"windGust", func(h *NwsGridHour) **float64 { return &h.WindGustMph }, toMph},
+	{"windDirection", func(h *NwsGridHour) **float64 { return &h.WindDirectionDeg }, unitless},
+	{"skyCover", func(h *NwsGridHour) **float64 { return &h.SkyCoverPct }, unitless},
+	{"probabilityOfPrecipitation", func(h *NwsGridHour) **float64 { return &h.PrecipProbabilityPct }, unitless},
+	{"visibility", func(h *NwsGridHour) **float64 { return &h.VisibilityMiles }, toMiles},
+	{"waveHeight", func(h *NwsGridHour) **float64 { return &h.WaveHeightFt }, toFeet},
+}
+
+// GetNwsGridData returns the spot's raw NWS gridpoint forecast expanded into
+// hourly numeric series in the spot's timezone.
+func GetNwsGridData(ctx tool.Context, a *NwsWindowArgs) (*NwsGridData, error) {
+	start, end, err := a.window()
+	if err != nil {
+		return nil, err
+	}
+
+	var gp *GridPoint
+	resBody, err := defaultGridPoints().fetch(ctx, a.Spot, func(p *GridPoint) string {
+		gp = p
+		return p.ForecastGridData
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var raw nwsGridResp
+	if err := json.Unmarshal(resBody, &raw); err != nil {
+		return nil, err
+	}
+	hours, err := raw.hours(a.Spot.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	data := &NwsGridData{
+		Office:   gp.Office,
+		GridX:    gp.GridX,
+		GridY:    gp.GridY,
+		Timezone: a.Spot.Location().String(),
+		Hours:    make([]NwsGridHour, 0, len(hours)),
+	}
+	for _, h := range hours {
+		if inWindow(h.Time, start, end) {
+			data.Hours = append(data.Hours, h)
+		}
+	}
+	return data, nil
+}
+
+// hours expands every layer's intervals into hourly values and merges them
+// into one record per hour, with times in loc.
+func (r *nwsGridResp) hours(loc *time.Location) ([]NwsGridHour, error) {
+	byTime := make(map[int64]*NwsGridHour)
+	for _, l := range nwsGridLayers {
+		raw, ok := r.Properties[l.name]
+		if !ok {
+			continue
+		}
+		var layer nwsLayer
+		if err := json.Unmarshal(raw, &layer); err != nil {
+			return nil, fmt.Errorf("decoding NWS %s: %w", l.name, err)
+		}
+
+		for _, v := range layer.Values {
+			if v.Value == nil {
+				continue
+			}
+			val, ok := l.convert(layer.Uom, *v.Value)
+			if !ok {
+				// A unit we can't convert; leave the layer out rather
+				// than mislabel it.
+				break
+			}
+			val = math.Round(val*10) / 10
+
+			start, dur, err := parseValidTime(v.ValidTime)
+			if err != nil {
+				return nil, fmt.Errorf("NWS %s: %w", l.name, err)
+			}
+			for t := start.Truncate(time.Hour); t.Before(start.Add(dur)); t = t.Add(time.Hour) {
+				h, ok := byTime[t.Unix()]
+				if !ok {
+					h = &NwsGridHour{Time: t.In(loc)}
+					byTime[t.Unix()] = h
+				}
+				*l.field(h) = &val
+			}
+		}
+	}
+
+	hours := make([]NwsGridHour, 0, len(byTime))
+	for _, h := range byTime {
+		hours = append(hours, *h)
+	}
+	slices.SortFunc(hours, func(a, b NwsGridHour) int { return a.Time.Compare(b.Time) })
+	return hours, nil
+}
+
+// parseValidTime parses an NWS validTime interval, e.g.
+// 2026-10-17T05:00:00+00:00/PT3H, into its start and duration.
+func parseValidTime(v string) (time.Time, time.Duration, error) {
+	startStr, durStr, ok := strings.Cut(v, "/")
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("validTime %q has no duration: %w", v, errInvalidIsoDuration)
+	}
+	start, err := time.Parse(time.RFC3339, startStr)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	dur, err := parseIsoDuration(durStr)
+	return start, dur, err
+}
+
+var isoDurationRe = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?)?$`)
+
+// parseIsoDuration parses the day, hour and minute ISO 8601 durations NWS
+// uses, e.g. PT1H, P1D or P2DT6H.
+func parseIsoDuration(v string) (time.Duration, error) {
+	m := isoDurationRe.FindStringSubmatch(v)
+	if m == nil || v == "P" || v == "PT" {
+		return 0, fmt.Errorf("%q: %w", v, errInvalidIsoDuration)
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("%q: %w", v, errInvalidIsoDuration)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+func toFahrenheit(uom string, v float64) (float64, bool) {
+	switch uom {
+	case "wmoUnit:degC":
+		return cToF(v), true
+	case "wmoUnit:degF":
+		return v, true
+	}
+	return 0, false
+}
+
+func toMph(uom string, v float64) (float64, bool) {
+	switch uom {
+	case "wmoUnit:km_h-1":
+		return v * 0.621371, true
+	case "wmoUnit:m_s-1":
+		return v * 2.23694, true
+	case "wmoUnit:kt":
+		return v * 1.15078, true
+	}
+	return 0, false
+}
+
+func toMiles(uom string, v float64) (float64, bool) {
+	switch uom {
+	case "wmoUnit:m":
+		return v / 1609.344, true
+	case "wmoUnit:km":
+		return v / 1.609344, true
+	}
+	return 0, false
+}
+
+func toFeet(uom string, v float64) (float64, bool) {
+	switch uom {
+	case "wmoUnit:m":
+		return v * 3.28084, true
+	case "wmoUnit:ft":
+		return v, true
+	}
+	return 0, false
+}
+
+// unitless passes through percentages and angles.
+func unitless(_ string, v float64) (float64, bool) {
+	return v, true
+}
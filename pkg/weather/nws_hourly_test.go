@@ -0,0 +1,80 @@
+package weather
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseIsoDuration(t *testing.T) {
+	testCases := []struct {
+		in       string
+		expected time.Duration
+		err      error
+	}{
+		{"PT1H", time.Hour, nil},
+		{"PT12H", 12 * time.Hour, nil},
+		{"P1D", 24 * time.Hour, nil},
+		{"P2DT6H", 54 * time.Hour, nil},
+		{"PT30M", 30 * time.Minute, nil},
+		{"P", 0, errInvalidIsoDuration},
+		{"3H", 0, errInvalidIsoDuration},
+	}
+
+	for _, tt := range testCases {
+		got, err := parseIsoDuration(tt.in)
+		if got != tt.expected || !errors.Is(err, tt.err) {
+			t.Fatalf("Returned duration did not match expected duration for %q:\n\tReturned: %v, %v\n\tExpected: %v, %v", tt.in, got, err, tt.expected, tt.err)
+		}
+	}
+}
+
+func TestNwsGridHours(t *testing.T) {
+	body := `{"properties": {
+		"windSpeed": {"uom": "wmoUnit:km_h-1", "values": [
+			{"validTime": "2026-10-17T12:00:00+00:00/PT2H", "value": 16.09},
+			{"validTime": "2026-10-17T14:00:00+00:00/PT1H", "value": null}
+		]},
+		"waveHeight": {"uom": "wmoUnit:m", "values": [
+			{"validTime": "2026-10-17T11:00:00+00:00/PT3H", "value": 1.22}
+		]},
+		"skyCover": {"uom": "wmoUnit:percent", "values": [
+			{"validTime": "2026-10-17T13:00:00+00:00/PT1H", "value": 40}
+		]},
+		"visibility": {"uom": "wmoUnit:furlong", "values": [
+			{"validTime": "2026-10-17T12:00:00+00:00/PT1H", "value": 8}
+		]}
+	}}`
+	var raw nwsGridResp
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatal(err)
+	}
+	loc, _ := time.LoadLocation("America/Chicago")
+	hours, err := raw.hours(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	times := []string{"2026-10-17T06:00:00-05:00", "2026-10-17T07:00:00-05:00", "2026-10-17T08:00:00-05:00"}
+	if len(hours) != len(times) {
+		t.Fatalf("expected %d hours, got %+v", len(times), hours)
+	}
+	for i, h := range hours {
+		if got := h.Time.Format(time.RFC3339); got != times[i] {
+			t.Fatalf("Returned time did not match expected time:\n\tReturned: %v\n\tExpected: %v", got, times[i])
+		}
+		if h.WaveHeightFt == nil || *h.WaveHeightFt != 4 {
+			t.Fatalf("expected 4ft waves every hour, got %+v", h)
+		}
+		if h.VisibilityMiles != nil {
+			t.Fatalf("expected visibility in an unknown unit to be left out, got %v", *h.VisibilityMiles)
+		}
+	}
+	if hours[0].WindSpeedMph != nil || *hours[1].WindSpeedMph != 10 || *hours[2].WindSpeedMph != 10 {
+		t.Fatalf("expected 10 mph wind from 7 to 9am, got %+v", hours)
+	}
+	if hours[2].SkyCoverPct == nil || *hours[2].SkyCoverPct != 40 || hours[1].SkyCoverPct != nil {
+		t.Fatalf("expected 40%% sky cover at 8am only, got %+v", hours)
+	}
+}
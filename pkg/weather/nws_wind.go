@@ -0,0 +1,118 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+// Wind speed units the NWS period forecasts can be parsed into.
+const (
+	WindUnitMph   = "mph"
+	WindUnitKnots = "kt"
+	WindUnitKmh   = "km/h"
+)
+
+var ErrInvalidWindUnit = errors.New("wind unit must be 'mph', 'kt' or 'km/h'")
+
+// mphPerUnit converts each wind unit to mph.
+var mphPerUnit = map[string]float64{
+	WindUnitMph:   1,
+	WindUnitKnots: 1.15078,
+	WindUnitKmh:   0.621371,
+}
+
+var (
+	// nwsGustRe matches the gust in strings like "W 20 G 30" or "10 mph with
+	// gusts as high as 25 mph".
+	nwsGustRe   = regexp.MustCompile(`(?i)(?:\bG|\bgusts?(?: as high as| up to| to)?)\s*(\d+(?:\.\d+)?)`)
+	nwsNumberRe = regexp.MustCompile(`\d+(?:\.\d+)?`)
+	nwsClockRe  = regexp.MustCompile(`(?i)\b\d{1,4}\s*[ap]m\b`)
+)
+
+func validWindUnit(unit string) (string, error) {
+	if unit == "" {
+		return WindUnitMph, nil
+	}
+	if _, ok := mphPerUnit[unit]; !ok {
+		return "", fmt.Errorf("%q: %w", unit, ErrInvalidWindUnit)
+	}
+	return unit, nil
+}
+
+// parseWind fills in the period's numeric wind speeds, in unit, and wind
+// direction from NWS's text. Fields NWS's text doesn't state are left unset.
+func (p *GridRespPeriod) parseWind(unit string) {
+	lo, hi, gust := parseNwsWindSpeed(p.WindSpeed)
+	if gust == nil && p.WindGustText != "" {
+		_, g, _ := parseNwsWindSpeed(p.WindGustText)
+		gust = g
+	}
+
+	convert := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		c := math.Round(*v/mphPerUnit[unit]*10) / 10
+		return &c
+	}
+	p.WindSpeedMin, p.WindSpeedMax, p.WindGustSpeed = convert(lo), convert(hi), convert(gust)
+	p.WindUnit = unit
+
+	p.WindDirectionDeg = nil
+	if d, err := spot.ParseDirection(p.WindDirection); err == nil {
+		deg := d.Degrees()
+		p.WindDirectionDeg = &deg
+	}
+}
+
+// parseNwsWindSpeed parses an NWS wind speed such as "15 mph", "5 to 10 mph",
+// "W 20 G 30" or "Calm" into its lowest, highest and gust speeds in mph.
+// Clock times such as "after 2 AM" are ignored.
+func parseNwsWindSpeed(s string) (lo, hi, gust *float64) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "calm") {
+		zero := 0.0
+		return &zero, &zero, nil
+	}
+
+	from := WindUnitMph
+	switch lower := strings.ToLower(s); {
+	case strings.Contains(lower, "km/h"):
+		from = WindUnitKmh
+	case strings.Contains(lower, "kt"), strings.Contains(lower, "knot"):
+		from = WindUnitKnots
+	}
+	toMph := func(v string) *float64 {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil
+		}
+		f *= mphPerUnit[from]
+		return &f
+	}
+
+	s = nwsClockRe.ReplaceAllString(s, "")
+	if m := nwsGustRe.FindStringSubmatchIndex(s); m != nil {
+		gust = toMph(s[m[2]:m[3]])
+		s = s[:m[0]] + s[m[1]:]
+	}
+	for _, n := range nwsNumberRe.FindAllString(s, -1) {
+		v := toMph(n)
+		if v == nil {
+			continue
+		}
+		if lo == nil || *v < *lo {
+			lo = v
+		}
+		if hi == nil || *v > *hi {
+			hi = v
+		}
+	}
+	return lo, hi, gust
+}
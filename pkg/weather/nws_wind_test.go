@@ -0,0 +1,64 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseWind(t *testing.T) {
+	testCases := []struct {
+		name      string
+		speed     string
+		gust      string
+		direction string
+		unit      string
+		min, max  float64
+		gustSpeed float64 // -1 when no gust is stated
+		degrees   float64 // -1 when the direction doesn't parse
+	}{
+		{name: "single speed", speed: "15 mph", direction: "W", unit: WindUnitMph, min: 15, max: 15, gustSpeed: -1, degrees: 270},
+		{name: "range", speed: "5 to 10 mph", direction: "SSE", unit: WindUnitMph, min: 5, max: 10, gustSpeed: -1, degrees: 157.5},
+		{name: "inline gust", speed: "W 20 G 30", direction: "W", unit: WindUnitMph, min: 20, max: 20, gustSpeed: 30, degrees: 270},
+		{name: "gust phrase", speed: "10 to 15 mph with gusts as high as 25 mph", direction: "NE", unit: WindUnitMph, min: 10, max: 15, gustSpeed: 25, degrees: 45},
+		{name: "separate gust", speed: "20 mph", gust: "35 mph", direction: "NW", unit: WindUnitMph, min: 20, max: 20, gustSpeed: 35, degrees: 315},
+		{name: "calm", speed: "Calm", direction: "", unit: WindUnitMph, min: 0, max: 0, gustSpeed: -1, degrees: -1},
+		{name: "to knots", speed: "10 to 20 mph", direction: "S", unit: WindUnitKnots, min: 8.7, max: 17.4, gustSpeed: -1, degrees: 180},
+		{name: "knots to km/h", speed: "10 kt", direction: "E", unit: WindUnitKmh, min: 18.5, max: 18.5, gustSpeed: -1, degrees: 90},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := GridRespPeriod{WindSpeed: tt.speed, WindGustText: tt.gust, WindDirection: tt.direction}
+			p.parseWind(tt.unit)
+
+			if p.WindSpeedMin == nil || p.WindSpeedMax == nil || *p.WindSpeedMin != tt.min || *p.WindSpeedMax != tt.max {
+				t.Fatalf("Returned speeds did not match expected speeds:\n\tReturned: %v to %v\n\tExpected: %v to %v", deref(p.WindSpeedMin), deref(p.WindSpeedMax), tt.min, tt.max)
+			}
+			if gust := deref(p.WindGustSpeed); gust != tt.gustSpeed {
+				t.Fatalf("Returned gust did not match expected gust:\n\tReturned: %v\n\tExpected: %v", gust, tt.gustSpeed)
+			}
+			if deg := deref(p.WindDirectionDeg); deg != tt.degrees {
+				t.Fatalf("Returned direction did not match expected direction:\n\tReturned: %v\n\tExpected: %v", deg, tt.degrees)
+			}
+			if p.WindUnit != tt.unit {
+				t.Fatalf("Returned unit did not match expected unit:\n\tReturned: %v\n\tExpected: %v", p.WindUnit, tt.unit)
+			}
+		})
+	}
+}
+
+func TestValidWindUnit(t *testing.T) {
+	if u, err := validWindUnit(""); err != nil || u != WindUnitMph {
+		t.Fatalf("expected mph by default, got %q, %v", u, err)
+	}
+	if _, err := validWindUnit("m/s"); !errors.Is(err, ErrInvalidWindUnit) {
+		t.Fatalf("expected ErrInvalidWindUnit, got %v", err)
+	}
+}
+
+func deref(v *float64) float64 {
+	if v == nil {
+		return -1
+	}
+	return *v
+}
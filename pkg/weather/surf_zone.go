@@ -0,0 +1,201 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+// Rip current risk levels used in NWS Surf Zone Forecasts.
+const (
+	RipRiskLow      = "Low"
+	RipRiskModerate = "Moderate"
+	RipRiskHigh     = "High"
+)
+
+var ErrNoSurfZoneForecast = errors.New("no NWS surf zone forecast for the spot")
+
+// srfFieldRe matches a "Surf height.........3 to 5 feet." line.
+var srfFieldRe = regexp.MustCompile(`^([A-Za-z][A-Za-z /()*-]*?)\s*\.{3,}\s*(.*)$`)
+
+type SurfZoneForecast struct {
+	Zone    string           `json:"zone" jsonschema_description:"NWS public forecast zone the surf zone forecast covers, e.g. 'CAZ043'."`
+	Name    string           `json:"name" jsonschema_description:"Zone name as written in the forecast."`
+	Office  string           `json:"office" jsonschema_description:"NWS office that issued the forecast."`
+	Issued  string           `json:"issued" jsonschema_description:"RFC3339 time the forecast was issued, in the spot's timezone."`
+	Hazards []string         `json:"hazards" jsonschema_description:"Active headlines for the zone, e.g. 'HIGH SURF ADVISORY IN EFFECT UNTIL 9 PM PDT FRIDAY'. Empty when none are in effect."`
+	Periods []SurfZonePeriod `json:"periods"`
+}
+
+type SurfZonePeriod struct {
+	Name           string            `json:"name" jsonschema_description:"Period name, e.g. 'TODAY' or 'FRIDAY'."`
+	RipCurrentRisk string            `json:"rip_current_risk,omitempty" jsonschema_description:"NWS rip current risk: 'Low', 'Moderate' or 'High'."`
+	SurfMinFt      *float64          `json:"surf_min_ft,omitempty" jsonschema_description:"Lower bound of the forecast surf height in feet; 0 for 'N feet or less'."`
+	SurfMaxFt      *float64          `json:"surf_max_ft,omitempty" jsonschema_description:"Upper bound of the forecast surf height in feet."`
+	WaterTempMinF  *float64          `json:"water_temp_min_f,omitempty" jsonschema_description:"Lower bound of the forecast water temperature in °F."`
+	WaterTempMaxF  *float64          `json:"water_temp_max_f,omitempty" jsonschema_description:"Upper bound of the forecast water temperature in °F."`
+	Hazards        []string          `json:"hazards" jsonschema_description:"Hazards for the period: a moderate or high rip current risk and any other threat the forecast rates above low, e.g. 'Lightning threat: Moderate'."`
+	Fields         map[string]string `json:"fields" jsonschema_description:"Every 'Name....value' line of the period as written, keyed by lowercase name, e.g. 'weather', 'winds', 'tides'."`
+	Text           string            `json:"text,omitempty" jsonschema_description:"Free text of the period, used by outlooks that have no fields."`
+}
+
+// GetSurfZoneForecast fetches the latest NWS Surf Zone Forecast (SRF) from
+// the spot's forecast office and returns the section for the spot's public
+// forecast zone, parsed into per-day rip current risk, surf height, water
+// temperature and hazards. Offices without beaches don't issue SRFs.
+// https://www.weather.gov/safety/ripcurrent-forecasts
+func GetSurfZoneForecast(ctx tool.Context, s *spot.Spot) (*SurfZoneForecast, error) {
+	return defaultGridPoints().surfZoneForecast(ctx, s)
+}
+
+func (c *GridPointCache) surfZoneForecast(ctx context.Context, s *spot.Spot) (*SurfZoneForecast, error) {
+	gp, err := c.Get(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	// Entries cached before forecast zones were recorded are looked up again.
+	if gp.ForecastZone == "" {
+		if gp, err = c.Refresh(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+	if gp.ForecastZone == "" {
+		return nil, fmt.Errorf("%s has no NWS forecast zone: %w", s.Name, ErrNoSurfZoneForecast)
+	}
+	zone := path.Base(gp.ForecastZone)
+
+	p, err := c.latestProduct(ctx, "SRF", gp.Office)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", gp.Office, err, ErrNoSurfZoneForecast)
+	}
+	f, ok := parseSurfZoneProduct(p.ProductText, zone)
+	if !ok {
+		return nil, fmt.Errorf("%s zone %s not in the %s SRF: %w", s.Name, zone, gp.Office, ErrNoSurfZoneForecast)
+	}
+	f.Zone = zone
+	f.Office = gp.Office
+	f.Issued = localizeTimestamp(s, p.IssuanceTime)
+	return f, nil
+}
+
+// parseSurfZoneProduct finds the segment of an SRF covering zone and parses
+// its headlines and ".PERIOD..." sections.
+func parseSurfZoneProduct(text, zone string) (*SurfZoneForecast, bool) {
+	lines, ok := productSegment(text, zone)
+	if !ok {
+		return nil, false
+	}
+
+	f := &SurfZoneForecast{Periods: make([]SurfZonePeriod, 0)}
+	var header []string
+	f.Name, header, lines = segmentHeader(lines)
+	f.Hazards = segmentHeadlines(header)
+
+	var p *SurfZonePeriod
+	field := ""
+	for _, l := range lines {
+		if m := marinePeriodRe.FindStringSubmatch(l); m != nil {
+			f.Periods = append(f.Periods, SurfZonePeriod{Name: m[1], Text: m[2], Fields: make(map[string]string)})
+			p, field = &f.Periods[len(f.Periods)-1], ""
+			continue
+		}
+		if p == nil || l == "" {
+			continue
+		}
+		if m := srfFieldRe.FindStringSubmatch(l); m != nil {
+			field = strings.ToLower(strings.TrimSpace(strings.Trim(m[1], "* ")))
+			p.Fields[field] = m[2]
+			continue
+		}
+		// Wrapped values continue the previous field; anything else is the
+		// period's free text.
+		if field != "" {
+			p.Fields[field] += " " + l
+		} else {
+			p.Text += " " + l
+		}
+	}
+
+	for i := range f.Periods {
+		f.Periods[i].parse()
+	}
+	return f, true
+}
+
+// parse fills in the period's structured fields from its "Name....value"
+// lines.
+func (p *SurfZonePeriod) parse() {
+	p.Text = strings.TrimSpace(strings.Join(strings.Fields(p.Text), " "))
+	p.Hazards = make([]string, 0)
+	for k, v := range p.Fields {
+		p.Fields[k] = strings.TrimSuffix(strings.Join(strings.Fields(v), " "), ".")
+	}
+
+	if v, ok := p.Fields["rip current risk"]; ok {
+		p.RipCurrentRisk = ripRisk(v)
+		if p.RipCurrentRisk == RipRiskModerate || p.RipCurrentRisk == RipRiskHigh {
+			p.Hazards = append(p.Hazards, p.RipCurrentRisk+" rip current risk")
+		}
+	}
+
+	for _, k := range []string{"surf height", "max surf height", "surf"} {
+		v, ok := p.Fields[k]
+		if !ok {
+			continue
+		}
+		if lo, hi := parseFeetRange(v); lo != nil {
+			p.SurfMinFt, p.SurfMaxFt = lo, hi
+			break
+		}
+	}
+
+	if v, ok := p.Fields["water temperature"]; ok {
+		for _, n := range nwsNumberRe.FindAllString(v, -1) {
+			t, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				continue
+			}
+			if p.WaterTempMinF == nil || t < *p.WaterTempMinF {
+				p.WaterTempMinF = &t
+			}
+			if p.WaterTempMaxF == nil || t > *p.WaterTempMaxF {
+				p.WaterTempMaxF = &t
+			}
+		}
+	}
+
+	// Some offices add their own threat lines, e.g. lightning or sneaker
+	// waves.
+	for _, k := range slices.Sorted(maps.Keys(p.Fields)) {
+		v := p.Fields[k]
+		if !strings.HasSuffix(k, "threat") && !strings.HasSuffix(k, "hazard") && !strings.HasSuffix(k, "hazards") {
+			continue
+		}
+		lower := strings.ToLower(v)
+		if lower == "" || strings.HasPrefix(lower, "low") || strings.HasPrefix(lower, "none") || strings.HasPrefix(lower, "no ") {
+			continue
+		}
+		p.Hazards = append(p.Hazards, fmt.Sprintf("%s%s: %s", strings.ToUpper(k[:1]), k[1:], v))
+	}
+}
+
+// ripRisk normalizes a rip current risk value such as "High" or "Moderate
+// becoming high in the afternoon" to the highest risk level it mentions.
+func ripRisk(v string) string {
+	lower := strings.ToLower(v)
+	for _, r := range []string{RipRiskHigh, RipRiskModerate, RipRiskLow} {
+		if strings.Contains(lower, strings.ToLower(r)) {
+			return r
+		}
+	}
+	return v
+}
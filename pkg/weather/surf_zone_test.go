@@ -0,0 +1,131 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+const testSrfProduct = `000
+FZUS56 KSGX 161015
+SRFSGX
+
+Surf Zone Forecast
+National Weather Service San Diego CA
+315 AM PDT Thu Oct 16 2026
+
+CAZ043-170300-
+San Diego County Coastal Areas-
+Including the beaches of Oceanside...Carlsbad...Ocean Beach
+315 AM PDT Thu Oct 16 2026
+
+...HIGH SURF ADVISORY IN EFFECT FROM 3 PM THIS AFTERNOON THROUGH
+FRIDAY EVENING...
+
+.TODAY...
+Rip Current Risk*...........Moderate becoming high in the
+                            afternoon.
+Surf Height.................3 to 5 feet.
+Water Temperature...........65 to 67 degrees.
+Lightning Threat............None.
+Weather.....................Sunny.
+Winds.......................West 5 to 10 mph.
+
+.FRIDAY...
+Rip Current Risk*...........High.
+Surf Height.................5 to 7 feet, sets to 8 feet.
+Water Temperature...........Around 64 degrees.
+Sneaker Wave Threat.........Moderate.
+
+.OUTLOOK...
+Surf subsides to 2 feet or less by Sunday.
+
+* Rip current risk is the risk of life-threatening rip currents.
+$$
+`
+
+func TestParseSurfZoneProduct(t *testing.T) {
+	f, ok := parseSurfZoneProduct(testSrfProduct, "CAZ043")
+	if !ok {
+		t.Fatal("expected CAZ043 to be found")
+	}
+	want := []string{"HIGH SURF ADVISORY IN EFFECT FROM 3 PM THIS AFTERNOON THROUGH FRIDAY EVENING"}
+	if !slices.Equal(f.Hazards, want) {
+		t.Fatalf("Returned hazards did not match expected hazards:\n\tReturned: %q\n\tExpected: %q", f.Hazards, want)
+	}
+	if f.Name != "San Diego County Coastal Areas" || len(f.Periods) != 3 {
+		t.Fatalf("unexpected forecast: %+v", f)
+	}
+
+	testCases := []struct {
+		name             string
+		rip              string
+		surfMin, surfMax float64
+		waterMin         float64
+		waterMax         float64
+		hazards          []string
+	}{
+		{name: "TODAY", rip: RipRiskHigh, surfMin: 3, surfMax: 5, waterMin: 65, waterMax: 67, hazards: []string{"High rip current risk"}},
+		{name: "FRIDAY", rip: RipRiskHigh, surfMin: 5, surfMax: 7, waterMin: 64, waterMax: 64, hazards: []string{"High rip current risk", "Sneaker wave threat: Moderate"}},
+		{name: "OUTLOOK", rip: "", surfMin: -1, surfMax: -1, waterMin: -1, waterMax: -1, hazards: []string{}},
+	}
+	for i, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := f.Periods[i]
+			if p.Name != tt.name || p.RipCurrentRisk != tt.rip {
+				t.Fatalf("Returned rip risk did not match expected rip risk:\n\tReturned: %s %q\n\tExpected: %s %q", p.Name, p.RipCurrentRisk, tt.name, tt.rip)
+			}
+			got := []float64{deref(p.SurfMinFt), deref(p.SurfMaxFt), deref(p.WaterTempMinF), deref(p.WaterTempMaxF)}
+			if want := []float64{tt.surfMin, tt.surfMax, tt.waterMin, tt.waterMax}; !slices.Equal(got, want) {
+				t.Fatalf("Returned surf and water did not match expected surf and water:\n\tReturned: %v\n\tExpected: %v", got, want)
+			}
+			if !slices.Equal(p.Hazards, tt.hazards) {
+				t.Fatalf("Returned hazards did not match expected hazards:\n\tReturned: %q\n\tExpected: %q", p.Hazards, tt.hazards)
+			}
+		})
+	}
+
+	if got := f.Periods[0].Fields["rip current risk"]; got != "Moderate becoming high in the afternoon" {
+		t.Fatalf("expected the wrapped rip current risk to be joined, got %q", got)
+	}
+	if got := f.Periods[2].Text; got != "Surf subsides to 2 feet or less by Sunday. * Rip current risk is the risk of life-threatening rip currents." {
+		t.Fatalf("unexpected outlook text %q", got)
+	}
+}
+
+func TestSurfZoneForecast(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/points/32.75,-117.25":
+			fmt.Fprintf(w, `{"properties": {"gridId": "SGX", "forecastZone": "%s/zones/forecast/CAZ043"}}`, srv.URL)
+		case "/zones":
+			fmt.Fprint(w, `{"features": []}`)
+		case "/products/types/SRF/locations/SGX":
+			fmt.Fprintf(w, `{"@graph": [{"id": "%s/products/srf-1"}]}`, srv.URL)
+		case "/products/srf-1":
+			b, _ := json.Marshal(nwsProduct{IssuanceTime: "2026-10-16T10:15:00+00:00", ProductText: testSrfProduct})
+			w.Write(b)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewGridPointCache("")
+	c.base = srv.URL
+	s := &spot.Spot{Name: "Ocean Beach", Latitude: 32.7499, Longitude: -117.2524, Facing: 270, Timezone: "America/Los_Angeles"}
+
+	f, err := c.surfZoneForecast(t.Context(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Zone != "CAZ043" || f.Office != "SGX" || f.Issued != "2026-10-16T03:15:00-07:00" || len(f.Periods) != 3 {
+		t.Fatalf("unexpected forecast: %+v", f)
+	}
+}
@@ -0,0 +1,102 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	SwellSourceBuoy     = "buoy"
+	SwellSourceForecast = "forecast"
+)
+
+var ErrNoSwellData = errors.New("no swell direction and height available")
+
+type EffectiveSwellArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	// Source picks where swell is read from when DirectionDeg and HeightFt
+	// aren't both provided.
+	Source       string   `json:"source,omitempty" jsonschema_description:"Where to read swell from when direction_deg and height_ft are not given: 'buoy' for the latest NDBC observation or 'forecast' for the hourly Open-Meteo swell forecast. Defaults to 'forecast'."`
+	DirectionDeg *float64 `json:"direction_deg,omitempty" jsonschema_description:"Swell direction in degrees true (where swell comes FROM). Provide with height_ft to evaluate a specific swell."`
+	HeightFt     *float64 `json:"height_ft,omitempty" jsonschema_description:"Swell height in feet. Provide with direction_deg to evaluate a specific swell."`
+}
+
+// EffectiveSwell is a single swell reading adjusted for the spot's shadowing.
+type EffectiveSwell struct {
+	Time              string   `json:"time,omitempty" jsonschema_description:"Time of the reading. Empty for caller-provided swell."`
+	DirectionDeg      float64  `json:"direction_deg" jsonschema_description:"Swell direction in degrees true (where swell comes FROM)."`
+	HeightFt          float64  `json:"height_ft" jsonschema_description:"Open-water swell height in feet."`
+	EnergyFactor      float64  `json:"energy_factor" jsonschema_description:"Fraction of swell energy reaching the spot, from 0 (fully blocked) to 1 (unobstructed)."`
+	EffectiveHeightFt float64  `json:"effective_height_ft" jsonschema_description:"Swell height in feet after shadowing, before any period-based breaking height adjustment."`
+	BlockedBy         []string `json:"blocked_by,omitempty" jsonschema_description:"Islands, headlands or the coastline that reduced the swell."`
+}
+
+type EffectiveSwellResp struct {
+	Source string           `json:"source" jsonschema_description:"Where the swell readings came from: 'input', 'buoy' or 'forecast'."`
+	Swells []EffectiveSwell `json:"swells"`
+}
+
+// GetEffectiveSwell applies the spot's swell shadows to either a
+// caller-provided swell, the latest buoy observation, or every hour of the
+// marine forecast, returning how much swell energy actually reaches the spot.
+func GetEffectiveSwell(ctx tool.Context, a *EffectiveSwellArgs) (*EffectiveSwellResp, error) {
+	if a.DirectionDeg != nil && a.HeightFt != nil {
+		return &EffectiveSwellResp{
+			Source: "input",
+			Swells: []EffectiveSwell{effectiveSwell(a.Spot, "", *a.DirectionDeg, *a.HeightFt)},
+		}, nil
+	}
+
+	switch a.Source {
+	case SwellSourceBuoy:
+		resp, err := GetBuoyObservations(ctx, a.Spot)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil || resp.Blended == nil || resp.Blended.MeanWaveDirDeg < 0 || resp.Blended.WaveHeightFt < 0 {
+			return nil, fmt.Errorf("buoys for %s: %w", a.Spot.Name, ErrNoSwellData)
+		}
+		obs := resp.Blended
+		return &EffectiveSwellResp{
+			Source: SwellSourceBuoy,
+			Swells: []EffectiveSwell{effectiveSwell(a.Spot, obs.ObservationTime, obs.MeanWaveDirDeg, obs.WaveHeightFt)},
+		}, nil
+
+	case SwellSourceForecast, "":
+		forecast, err := GetHourlyMarineForecast(ctx, a.Spot)
+		if err != nil {
+			return nil, err
+		}
+
+		swells := make([]EffectiveSwell, 0, len(forecast.Hours))
+		for _, h := range forecast.Hours {
+			if h.SwellWaveDirection == nil || h.SwellWaveHeight == nil {
+				continue
+			}
+			swells = append(swells, effectiveSwell(a.Spot, h.Time.Format(time.RFC3339), *h.SwellWaveDirection, *h.SwellWaveHeight))
+		}
+		if len(swells) == 0 {
+			return nil, fmt.Errorf("marine forecast for %s: %w", a.Spot.Name, ErrNoSwellData)
+		}
+		return &EffectiveSwellResp{Source: SwellSourceForecast, Swells: swells}, nil
+	}
+	return nil, fmt.Errorf("unknown swell source %q, expected %q or %q", a.Source, SwellSourceBuoy, SwellSourceForecast)
+}
+
+func effectiveSwell(s *spot.Spot, time string, dirDeg, heightFt float64) EffectiveSwell {
+	e := s.Exposure(spot.Direction(dirDeg))
+	return EffectiveSwell{
+		Time:              time,
+		DirectionDeg:      dirDeg,
+		HeightFt:          heightFt,
+		EnergyFactor:      math.Round(e.EnergyFactor*100) / 100,
+		EffectiveHeightFt: math.Round(heightFt*e.HeightFactor*10) / 10,
+		BlockedBy:         e.BlockedBy,
+	}
+}
@@ -0,0 +1,31 @@
+package weather
+
+import (
+	"testing"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestGetEffectiveSwellInput(t *testing.T) {
+	s := &spot.Spot{
+		Name:   "Rincon Point",
+		Facing: 225,
+		Shadows: []spot.Shadow{
+			{Name: "Channel Islands", Sector: spot.DirectionArc{From: 185, To: 245}, Attenuation: 0.75},
+		},
+	}
+	dir, height := 230.0, 6.0
+
+	resp, err := GetEffectiveSwell(nil, &EffectiveSwellArgs{Spot: s, DirectionDeg: &dir, HeightFt: &height})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Swells) != 1 {
+		t.Fatalf("Expected 1 swell, got %d", len(resp.Swells))
+	}
+
+	got := resp.Swells[0]
+	if got.EnergyFactor != 0.25 || got.EffectiveHeightFt != 3 {
+		t.Fatalf("Expected energy factor 0.25 and effective height 3ft, got %g and %gft", got.EnergyFactor, got.EffectiveHeightFt)
+	}
+}
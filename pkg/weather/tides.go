@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -12,7 +14,12 @@ import (
 	"google.golang.org/adk/tool"
 )
 
-const tideTimeFormat = "20060102"
+const (
+	// tideTimeFormat is the begin_date/end_date layout, in GMT.
+	tideTimeFormat = "20060102 15:04"
+	// coopsTimeFormat is the layout of prediction times, in GMT.
+	coopsTimeFormat = "2006-01-02 15:04"
+)
 
 type TidePredictionArgs struct {
 	Spot *spot.Spot
@@ -23,16 +30,22 @@ type TidePredictionArgs struct {
 
 // TidePrediction is a single high or low tide event from the NOAA CO-OPS API.
 type TidePrediction struct {
-	Time     string  `json:"time"`
+	Time     string  `json:"time" jsonschema_description:"Time of the high or low tide in RFC3339, in the spot's timezone."`
 	HeightFt float64 `json:"height_ft"`
 	// Type is "H" (high tide) or "L" (low tide).
 	Type string `json:"type"`
 }
 
-// TidePredictionsResp holds today's and tomorrow's high/low tide predictions.
+// TidePredictionsResp holds high/low tide predictions from the start of the
+// spot's local day.
 type TidePredictionsResp struct {
-	StationID   string           `json:"station_id"`
-	Predictions []TidePrediction `json:"predictions"`
+	StationID     string           `json:"station_id"`
+	StationName   string           `json:"station_name,omitempty"`
+	StationType   string           `json:"station_type,omitempty" jsonschema_description:"'harmonic' or 'subordinate'. Subordinate predictions are derived from reference_id with time and height offsets."`
+	ReferenceID   string           `json:"reference_id,omitempty" jsonschema_description:"Harmonic reference station used for subordinate predictions."`
+	DistanceMiles float64          `json:"distance_miles,omitempty" jsonschema_description:"Distance from the spot to the station in miles."`
+	Note          string           `json:"note,omitempty" jsonschema_description:"Caveat about how the predictions were derived."`
+	Predictions   []TidePrediction `json:"predictions"`
 }
 
 // coopsPrediction matches the raw JSON shape returned by the CO-OPS API.
@@ -46,31 +59,108 @@ type coopsResp struct {
 	Predictions []coopsPrediction `json:"predictions"`
 }
 
-// GetTidePredictions fetches today's and tomorrow's high/low tide predictions
-// from the NOAA CO-OPS API for the spot's configured tide gauge station.
+// GetTidePredictions fetches high/low tide predictions for today and the
+// following Days days from the NOAA CO-OPS API for the spot's tide gauge station. Ocean spots
+// without a configured station use the nearest prediction station within
+// 50 miles. Subordinate stations are predicted from their harmonic reference
+// station with the station's time and height offsets applied; when the offsets
+// can't be loaded the station is queried directly and the response notes that
+// no offsets were applied.
 // Returns nil without error for lake spots (tides negligible) or spots with no
-// station configured.
+// usable station.
+// Predictions are requested in GMT and converted to the spot's timezone.
 // https://api.tidesandcurrents.noaa.gov/api/prod
-func GetTidePredictions(_ tool.Context, a *TidePredictionArgs) (*TidePredictionsResp, error) {
-	if a.Spot.TideStationID == "" || a.Spot.TideStationID == "N/A" {
+func GetTidePredictions(ctx tool.Context, a *TidePredictionArgs) (*TidePredictionsResp, error) {
+	catalog := loadTideCatalog(ctx)
+
+	station, ok := catalog.Lookup(a.Spot.TideStationID)
+	switch {
+	case a.Spot.TideStationID == "" && a.Spot.SpotType == spot.SpotTypeOcean:
+		var miles float64
+		station, miles, ok = catalog.Nearest(float64(a.Spot.Latitude), float64(a.Spot.Longitude))
+		if !ok || miles > maxTideStationMiles {
+			return nil, nil
+		}
+	case a.Spot.TideStationID == "":
 		return nil, nil
+	case !ok:
+		// Not in the catalog, so predict straight from the configured ID.
+		station = TideStation{ID: a.Spot.TideStationID, Type: TideStationHarmonic}
+	}
+
+	// Cover whole local days, from midnight today through the end of the last
+	// requested day.
+	now := a.Spot.Now()
+	begin := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	end := begin.AddDate(0, 0, a.Days+1).Add(-time.Minute)
+
+	resp := &TidePredictionsResp{
+		StationID:   station.ID,
+		StationName: station.Name,
+		StationType: "harmonic",
+	}
+	if station.Lat != 0 || station.Lon != 0 {
+		resp.DistanceMiles = math.Round(a.Spot.DistanceTo(station.Lat, station.Lon)*10) / 10
+	}
+
+	if station.Subordinate() {
+		resp.StationType = "subordinate"
+		offsets, err := catalog.offsets(ctx, station.ID)
+		if err != nil || offsets == nil {
+			resp.Note = "Offsets from the harmonic reference station couldn't be loaded, so no offsets were applied; predictions were requested for this subordinate station directly."
+		} else {
+			refPredictions, err := fetchCoopsHilo(offsets.RefStationID, begin, end)
+			if err != nil {
+				return nil, err
+			}
+
+			resp.ReferenceID = offsets.RefStationID
+			resp.Predictions = make([]TidePrediction, 0, len(refPredictions))
+			for _, p := range refPredictions {
+				if p, err = offsets.apply(p); err == nil {
+					resp.Predictions = append(resp.Predictions, p)
+				}
+			}
+			localizePredictions(a.Spot, resp.Predictions)
+			return resp, nil
+		}
 	}
 
-	now := time.Now()
-	begin := now.Format(tideTimeFormat)
-	end := now.AddDate(0, 0, a.Days).Format(tideTimeFormat)
+	predictions, err := fetchCoopsHilo(station.ID, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	resp.Predictions = predictions
+	localizePredictions(a.Spot, resp.Predictions)
+	return resp, nil
+}
 
-	url := fmt.Sprintf(
+// localizePredictions converts GMT prediction times to RFC3339 in the spot's
+// timezone.
+func localizePredictions(s *spot.Spot, predictions []TidePrediction) {
+	for i, p := range predictions {
+		if t, err := parseCoopsTime(p.Time); err == nil {
+			predictions[i].Time = localTime(s, t)
+		}
+	}
+}
+
+// fetchCoopsHilo fetches high/low tide predictions for a station between begin
+// and end inclusive. Prediction times are in GMT.
+func fetchCoopsHilo(stationID string, begin, end time.Time) ([]TidePrediction, error) {
+	u := fmt.Sprintf(
 		"https://api.tidesandcurrents.noaa.gov/api/prod/datagetter"+
 			"?station=%s&product=predictions&datum=MLLW"+
-			"&time_zone=lst_ldt&interval=hilo&units=english&format=json"+
+			"&time_zone=gmt&interval=hilo&units=english&format=json"+
 			"&begin_date=%s&end_date=%s",
-		a.Spot.TideStationID, begin, end,
+		stationID,
+		url.QueryEscape(begin.UTC().Format(tideTimeFormat)),
+		url.QueryEscape(end.UTC().Format(tideTimeFormat)),
 	)
 
-	resp, err := http.Get(url)
+	resp, err := http.Get(u)
 	if err != nil {
-		return nil, fmt.Errorf("fetching tide predictions for station %s: %w", a.Spot.TideStationID, err)
+		return nil, fmt.Errorf("fetching tide predictions for station %s: %w", stationID, err)
 	}
 	defer resp.Body.Close()
 
@@ -100,9 +190,10 @@ func GetTidePredictions(_ tool.Context, a *TidePredictionArgs) (*TidePredictions
 			Type:     p.Type,
 		})
 	}
+	return predictions, nil
+}
 
-	return &TidePredictionsResp{
-		StationID:   a.Spot.TideStationID,
-		Predictions: predictions,
-	}, nil
+// parseCoopsTime parses a GMT CO-OPS prediction timestamp.
+func parseCoopsTime(t string) (time.Time, error) {
+	return time.Parse(coopsTimeFormat, t)
 }
@@ -0,0 +1,219 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+const (
+	// buoyWaterTempMaxAge is how old a buoy water temperature may be and still
+	// be preferred over the forecast sea surface temperature.
+	buoyWaterTempMaxAge = 3 * time.Hour
+
+	defaultSessionHours = 2.0
+
+	// longSessionHours is the session length past which a warmer suit is
+	// recommended.
+	longSessionHours = 3.0
+
+	// windChillGapF is how far the wind chill may fall below the water
+	// temperature before a warmer suit is recommended.
+	windChillGapF = 15.0
+
+	// coldAirF is the wind chill below which a warmer suit is recommended
+	// regardless of the water temperature.
+	coldAirF = 40.0
+)
+
+// Sources of the water temperature a recommendation is based on.
+const (
+	WaterTempSourceBuoy     = "buoy"
+	WaterTempSourceForecast = "forecast"
+)
+
+var ErrNoWaterTemp = errors.New("no water temperature available")
+
+// wetsuitTier is the gear for water at or above minWaterF.
+type wetsuitTier struct {
+	minWaterF float64
+	suit      string
+	boots     string
+	gloves    string
+	hood      string
+}
+
+// wetsuitTiers run from warmest to coldest water.
+var wetsuitTiers = []wetsuitTier{
+	{75, "none (boardshorts or swimsuit)", "none", "none", "none"},
+	{70, "2mm springsuit or wetsuit top", "none", "none", "none"},
+	{63, "3/2mm full suit", "none", "none", "none"},
+	{57, "4/3mm full suit", "optional", "none", "none"},
+	{52, "4/3mm full suit", "3mm", "optional", "optional"},
+	{47, "5/4mm hooded full suit", "5mm", "3mm", "yes"},
+	{42, "5/4mm hooded full suit", "7mm", "5mm", "yes"},
+	{math.Inf(-1), "6/5mm hooded full suit", "7mm", "7mm mittens", "yes"},
+}
+
+type WetsuitArgs struct {
+	Spot *spot.Spot `json:"spot"`
+
+	Start        string  `json:"start,omitempty" jsonschema_description:"When the session starts, as RFC3339 or as 'YYYY-MM-DDTHH:MM' in the spot's timezone. Defaults to now."`
+	SessionHours float64 `json:"session_hours,omitempty" jsonschema_description:"Planned session length in hours. Defaults to 2."`
+}
+
+type WetsuitRecommendation struct {
+	WaterTempF      float64  `json:"water_temp_f" jsonschema_description:"Water temperature the recommendation is based on, in °F."`
+	WaterTempSource string   `json:"water_temp_source" jsonschema_description:"'buoy' when a buoy reading from the last 3 hours was used, otherwise 'forecast' for the Open-Meteo sea surface temperature at the session start."`
+	AirTempF        *float64 `json:"air_temp_f,omitempty" jsonschema_description:"Coldest forecast air temperature during the session in °F."`
+	WindMph         *float64 `json:"wind_mph,omitempty" jsonschema_description:"Strongest forecast sustained wind during the session in mph."`
+	WindChillF      *float64 `json:"wind_chill_f,omitempty" jsonschema_description:"Coldest wind chill during the session in °F. Equals the air temperature when it's too warm or calm for wind chill to apply."`
+	SessionHours    float64  `json:"session_hours"`
+	Suit            string   `json:"suit" jsonschema_description:"Wetsuit thickness and style, e.g. '4/3mm full suit'."`
+	Boots           string   `json:"boots" jsonschema_description:"Boot thickness, 'optional' or 'none'."`
+	Gloves          string   `json:"gloves" jsonschema_description:"Glove thickness, 'optional' or 'none'."`
+	Hood            string   `json:"hood" jsonschema_description:"'yes', 'optional' or 'none'."`
+	Reasons         []string `json:"reasons" jsonschema_description:"Why the recommendation was adjusted from the water temperature alone."`
+}
+
+// RecommendWetsuit recommends a wetsuit, boots, gloves and hood for a session
+// at the spot from the water temperature, adjusted warmer for cold wind chill
+// and long sessions.
+func RecommendWetsuit(ctx tool.Context, a *WetsuitArgs) (*WetsuitRecommendation, error) {
+	if a.Spot == nil {
+		return nil, fmt.Errorf("spot is required: %w", ErrInvalidArgs)
+	}
+	if a.SessionHours < 0 {
+		return nil, fmt.Errorf("session_hours must be positive: %w", ErrInvalidArgs)
+	}
+	hours := a.SessionHours
+	if hours == 0 {
+		hours = defaultSessionHours
+	}
+
+	start := a.Spot.Now()
+	if a.Start != "" {
+		var err error
+		if start, err = parseWindowTime(a.Start, a.Spot.Location(), false); err != nil {
+			return nil, err
+		}
+	}
+
+	rec := &WetsuitRecommendation{SessionHours: hours}
+	var err error
+	if rec.WaterTempF, rec.WaterTempSource, err = waterTempF(ctx, a.Spot, start); err != nil {
+		return nil, err
+	}
+
+	if wind, err := GetHourlyWindForecast(ctx, a.Spot); err == nil {
+		rec.AirTempF, rec.WindMph, rec.WindChillF = sessionWeather(wind, start, start.Add(time.Duration(hours*float64(time.Hour))))
+	}
+
+	tier, reasons := wetsuitFor(rec.WaterTempF, rec.WindChillF, hours)
+	rec.Suit, rec.Boots, rec.Gloves, rec.Hood = tier.suit, tier.boots, tier.gloves, tier.hood
+	rec.Reasons = reasons
+	if rec.AirTempF == nil {
+		rec.Reasons = append(rec.Reasons, "no air temperature forecast for the session, so wind chill wasn't considered")
+	}
+	return rec, nil
+}
+
+// waterTempF returns the water temperature at the spot in °F, preferring a
+// fresh buoy reading over the forecast sea surface temperature at start.
+func waterTempF(ctx tool.Context, s *spot.Spot, start time.Time) (float64, string, error) {
+	if obs, err := GetBuoyObservations(ctx, s); err == nil && obs != nil && obs.Blended != nil && obs.Blended.WaterTempC != -1 {
+		t, err := time.Parse(time.RFC3339, obs.Blended.WaterTempTime)
+		if err == nil && time.Since(t) <= buoyWaterTempMaxAge {
+			return round1(cToF(obs.Blended.WaterTempC)), WaterTempSourceBuoy, nil
+		}
+	}
+
+	forecast, err := fetchMarineForecast(s, 0, 0)
+	if err != nil {
+		return 0, "", fmt.Errorf("marine forecast for %s: %v: %w", s.Name, err, ErrNoWaterTemp)
+	}
+	h, ok := forecast.Hours.At(start)
+	if !ok || h.SeaSurfaceTemperature == nil {
+		return 0, "", fmt.Errorf("%s at %s: %w", s.Name, start.Format(time.RFC3339), ErrNoWaterTemp)
+	}
+	return round1(cToF(*h.SeaSurfaceTemperature)), WaterTempSourceForecast, nil
+}
+
+// sessionWeather returns the coldest air temperature, strongest wind and
+// coldest wind chill forecast between start and end.
+func sessionWeather(w *WindForecastResp, start, end time.Time) (air, wind, chill *float64) {
+	for _, h := range w.Hours {
+		if !h.Time.Add(time.Hour).After(start) || !h.Time.Before(end) {
+			continue
+		}
+		if h.Temperature2m == nil || h.WindSpeed10m == nil {
+			continue
+		}
+
+		a, v := *h.Temperature2m, *h.WindSpeed10m
+		c := windChillF(a, v)
+		if air == nil || a < *air {
+			air = &a
+		}
+		if wind == nil || v > *wind {
+			wind = &v
+		}
+		if chill == nil || c < *chill {
+			chill = &c
+		}
+	}
+	if chill != nil {
+		r := round1(*chill)
+		chill = &r
+	}
+	return air, wind, chill
+}
+
+// windChillF returns the NWS wind chill in °F. It only applies at or below
+// 50°F with more than 3 mph of wind; otherwise the air temperature is
+// returned.
+func windChillF(airF, windMph float64) float64 {
+	if airF > 50 || windMph <= 3 {
+		return airF
+	}
+	v := math.Pow(windMph, 0.16)
+	return 35.74 + 0.6215*airF - 35.75*v + 0.4275*airF*v
+}
+
+// wetsuitFor picks the tier for the water temperature and steps one tier
+// warmer each for a cold wind chill and a long session.
+func wetsuitFor(waterF float64, windChillF *float64, sessionHours float64) (wetsuitTier, []string) {
+	i := 0
+	for i < len(wetsuitTiers)-1 && waterF < wetsuitTiers[i].minWaterF {
+		i++
+	}
+
+	reasons := make([]string, 0)
+	if windChillF != nil {
+		switch {
+		case *windChillF < coldAirF:
+			i++
+			reasons = append(reasons, fmt.Sprintf("wind chill of %.0f°F out of the water", *windChillF))
+		case waterF-*windChillF > windChillGapF:
+			i++
+			reasons = append(reasons, fmt.Sprintf("wind chill of %.0f°F is well below the %.0f°F water", *windChillF, waterF))
+		}
+	}
+	if sessionHours >= longSessionHours {
+		i++
+		reasons = append(reasons, fmt.Sprintf("%.1f hour session", sessionHours))
+	}
+	return wetsuitTiers[min(i, len(wetsuitTiers)-1)], reasons
+}
+
+func cToF(c float64) float64 {
+	return c*9/5 + 32
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
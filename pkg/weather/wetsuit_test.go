@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestWindChillF(t *testing.T) {
+	testCases := []struct {
+		air, wind, expected float64
+	}{
+		{30, 15, 19.0},
+		{0, 20, -22.0},
+		{60, 20, 60},
+		{30, 2, 30},
+	}
+
+	for _, tt := range testCases {
+		if got := round1(windChillF(tt.air, tt.wind)); got != tt.expected {
+			t.Fatalf("Returned wind chill did not match expected wind chill:\n\tReturned: %v\n\tExpected: %v", got, tt.expected)
+		}
+	}
+}
+
+func TestWetsuitFor(t *testing.T) {
+	chill := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name     string
+		waterF   float64
+		chillF   *float64
+		hours    float64
+		suit     string
+		boots    string
+		nReasons int
+	}{
+		{name: "warm water", waterF: 78, chillF: chill(80), hours: 2, suit: "none (boardshorts or swimsuit)", boots: "none"},
+		{name: "mild water", waterF: 64, chillF: chill(62), hours: 2, suit: "3/2mm full suit", boots: "none"},
+		{name: "cold wind steps warmer", waterF: 64, chillF: chill(45), hours: 2, suit: "4/3mm full suit", boots: "optional", nReasons: 1},
+		{name: "long session steps warmer", waterF: 64, hours: 4, suit: "4/3mm full suit", boots: "optional", nReasons: 1},
+		{name: "winter lake", waterF: 36, chillF: chill(5), hours: 3, suit: "6/5mm hooded full suit", boots: "7mm", nReasons: 2},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			tier, reasons := wetsuitFor(tt.waterF, tt.chillF, tt.hours)
+			if tier.suit != tt.suit || tier.boots != tt.boots || len(reasons) != tt.nReasons {
+				t.Fatalf("Returned recommendation did not match expected recommendation:\n\tReturned: %+v %v\n\tExpected: %s, %s boots, %d reasons", tier, reasons, tt.suit, tt.boots, tt.nReasons)
+			}
+		})
+	}
+}
+
+func TestSessionWeather(t *testing.T) {
+	start := time.Date(2026, 10, 17, 6, 30, 0, 0, time.UTC)
+	w := &WindForecastResp{}
+	for i, v := range [][2]float64{{20, 30}, {40, 10}, {35, 5}, {45, 20}, {10, 30}} {
+		w.Hours = append(w.Hours, WindHour{Time: start.Truncate(time.Hour).Add(time.Duration(i-1) * time.Hour), Temperature2m: ptr(v[0]), WindSpeed10m: ptr(v[1])})
+	}
+	// An hour with no temperature is skipped rather than read as 0°F.
+	w.Hours[2].Temperature2m = nil
+
+	air, wind, chill := sessionWeather(w, start, start.Add(2*time.Hour))
+	got := []float64{*air, *wind, *chill}
+	expected := []float64{40, 20, round1(math.Min(windChillF(40, 10), windChillF(45, 20)))}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("Returned session weather did not match expected weather:\n\tReturned: %v\n\tExpected: %v", got, expected)
+	}
+}
+
+func TestRecommendWetsuitInvalidArgs(t *testing.T) {
+	testCases := []struct {
+		name string
+		args *WetsuitArgs
+	}{
+		{name: "no spot", args: &WetsuitArgs{}},
+		{name: "negative session", args: &WetsuitArgs{Spot: &spot.Spot{Name: "Ocean Beach"}, SessionHours: -1}},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RecommendWetsuit(nil, tt.args); !errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("Returned error did not match expected error:\n\tReturned: %v\n\tExpected: %v", err, ErrInvalidArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+	"google.golang.org/adk/tool"
+)
+
+// https://open-meteo.com/en/docs
+
+// windBaseUrl is the Open-Meteo forecast endpoint, swapped out in tests.
+var windBaseUrl = "https://api.open-meteo.com/v1/forecast"
+
+// WindForecastResp is the hourly Open-Meteo weather forecast for a spot as one
+// record per hour.
+type WindForecastResp struct {
+	Timezone    string          `json:"timezone" jsonschema_description:"IANA timezone of the hourly times, which are RFC3339 with the UTC offset included."`
+	HourlyUnits WindHourlyUnits `json:"hourly_units"`
+	Hours       []WindHour      `json:"hours" jsonschema_description:"One record per forecast hour, in time order."`
+}
+
+type WindHourlyUnits struct {
+	Time             string `json:"time"`
+	WindSpeed10m     string `json:"wind_speed_10m"`
+	WindGusts10m     string `json:"wind_gusts_10m"`
+	WindDirection10m string `json:"wind_direction_10m"`
+	Temperature2m    string `json:"temperature_2m"`
+	PressureMsl      string `json:"pressure_msl"`
+}
+
+// WindHour is the weather forecast for one hour. Values Open-Meteo has no data
+// for are omitted.
+type WindHour struct {
+	Time             time.Time `json:"time" jsonschema_description:"Start of the hour, RFC3339 in the spot's timezone."`
+	WindSpeed10m     *float64  `json:"wind_speed_10m,omitempty" jsonschema_description:"Sustained wind speed 10m above ground in mph."`
+	WindGusts10m     *float64  `json:"wind_gusts_10m,omitempty" jsonschema_description:"Wind gusts 10m above ground in mph."`
+	WindDirection10m *float64  `json:"wind_direction_10m,omitempty" jsonschema_description:"Direction the wind blows from in degrees true."`
+	Temperature2m    *float64  `json:"temperature_2m,omitempty" jsonschema_description:"Air temperature 2m above ground in °F."`
+	PressureMsl      *float64  `json:"pressure_msl,omitempty" jsonschema_description:"Sea level pressure in hPa. Falling pressure signals an approaching low and building wind."`
+}
+
+// windColumns are the hourly variables requested from Open-Meteo.
+var windColumns = []openMeteoColumn[WindHour]{
+	{"wind_speed_10m", func(h *WindHour) **float64 { return &h.WindSpeed10m }},
+	{"wind_gusts_10m", func(h *WindHour) **float64 { return &h.WindGusts10m }},
+	{"wind_direction_10m", func(h *WindHour) **float64 { return &h.WindDirection10m }},
+	{"temperature_2m", func(h *WindHour) **float64 { return &h.Temperature2m }},
+	{"pressure_msl", func(h *WindHour) **float64 { return &h.PressureMsl }},
+}
+
+// openMeteoWindResp is Open-Meteo's column-oriented forecast response, decoded
+// with openMeteoHours like the marine forecast.
+type openMeteoWindResp struct {
+	HourlyUnits WindHourlyUnits            `json:"hourly_units"`
+	Hourly      map[string]json.RawMessage `json:"hourly"`
+}
+
+// GetHourlyWindForecast fetches the hourly Open-Meteo wind, air temperature and
+// pressure forecast for the spot, with times in the spot's timezone. Unlike
+// the NWS grid forecast it covers lake and coastal coordinates everywhere.
+func GetHourlyWindForecast(ctx tool.Context, s *spot.Spot) (*WindForecastResp, error) {
+	return fetchWindForecast(s)
+}
+
+func fetchWindForecast(s *spot.Spot) (*WindForecastResp, error) {
+	resp, err := http.Get(generateWindUrl(s))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrInvalidHttpResponse
+	}
+
+	resBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw openMeteoWindResp
+	if err := json.Unmarshal(resBody, &raw); err != nil {
+		return nil, err
+	}
+	loc := s.Location()
+	hours, err := openMeteoHours(raw.Hourly, windColumns, loc, func(t time.Time) WindHour { return WindHour{Time: t} })
+	if err != nil {
+		return nil, err
+	}
+	return &WindForecastResp{
+		Timezone:    loc.String(),
+		HourlyUnits: raw.HourlyUnits,
+		Hours:       hours,
+	}, nil
+}
+
+func generateWindUrl(s *spot.Spot) string {
+	return fmt.Sprintf("%s?latitude=%.2f&longitude=%.2f&hourly=%s&wind_speed_unit=mph&temperature_unit=fahrenheit&timezone=%s", windBaseUrl, s.Latitude, s.Longitude, strings.Join(openMeteoVars(windColumns), ","), url.QueryEscape(s.Location().String()))
+}
@@ -0,0 +1,77 @@
+package weather
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/louislef299/wave-report-agent/pkg/spot"
+)
+
+func TestFetchWindForecastNulls(t *testing.T) {
+	testCases := []struct {
+		name   string
+		hourly string
+		// expected holds each hour's speed, gusts, direction, temperature
+		// and pressure, with -1 for a missing value.
+		expected [][]float64
+	}{
+		{
+			name:   "all values",
+			hourly: `"time": ["2026-10-17T05:00", "2026-10-17T06:00"], "wind_speed_10m": [12.3, 14], "wind_gusts_10m": [20, 22.5], "wind_direction_10m": [270, 275], "temperature_2m": [48.2, 47], "pressure_msl": [1012.4, 1011.8]`,
+			expected: [][]float64{
+				{12.3, 20, 270, 48.2, 1012.4},
+				{14, 22.5, 275, 47, 1011.8},
+			},
+		},
+		{
+			name:   "null hours past the model horizon",
+			hourly: `"time": ["2026-10-17T05:00", "2026-10-17T06:00"], "wind_speed_10m": [12.3, null], "wind_gusts_10m": [null, null], "wind_direction_10m": [270, null], "temperature_2m": [48.2, 47], "pressure_msl": [null, 1011.8]`,
+			expected: [][]float64{
+				{12.3, -1, 270, 48.2, -1},
+				{-1, -1, -1, 47, 1011.8},
+			},
+		},
+		{
+			name:   "missing and short columns",
+			hourly: `"time": ["2026-10-17T05:00", "2026-10-17T06:00"], "wind_speed_10m": [12.3], "temperature_2m": [48.2, 47]`,
+			expected: [][]float64{
+				{12.3, -1, -1, 48.2, -1},
+				{-1, -1, -1, 47, -1},
+			},
+		},
+	}
+
+	s := &spot.Spot{Name: "Stoney Point", Latitude: 46.97, Longitude: -91.64, Timezone: "America/Chicago"}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"hourly_units": {"wind_speed_10m": "mp/h"}, "hourly": {%s}}`, tt.hourly)
+			}))
+			t.Cleanup(srv.Close)
+			orig := windBaseUrl
+			windBaseUrl = srv.URL
+			t.Cleanup(func() { windBaseUrl = orig })
+
+			resp, err := fetchWindForecast(s)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Hours) != len(tt.expected) {
+				t.Fatalf("expected %d hours, got %d", len(tt.expected), len(resp.Hours))
+			}
+			for i, h := range resp.Hours {
+				got := []float64{deref(h.WindSpeed10m), deref(h.WindGusts10m), deref(h.WindDirection10m), deref(h.Temperature2m), deref(h.PressureMsl)}
+				if !slices.Equal(got, tt.expected[i]) {
+					t.Fatalf("Returned hour %d did not match expected hour:\n\tReturned: %v\n\tExpected: %v", i, got, tt.expected[i])
+				}
+			}
+			if got := resp.Hours[0].Time.Format(time.RFC3339); got != "2026-10-17T05:00:00-05:00" {
+				t.Fatalf("Returned time did not match expected time:\n\tReturned: %s\n\tExpected: %s", got, "2026-10-17T05:00:00-05:00")
+			}
+		})
+	}
+}